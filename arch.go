@@ -0,0 +1,91 @@
+package webview2runtime
+
+import (
+	"debug/pe"
+	"fmt"
+	"path/filepath"
+	"runtime"
+)
+
+// Arch is a processor architecture.
+type Arch int
+
+const (
+	ArchUnknown Arch = iota
+	ArchX86
+	ArchX64
+	ArchARM64
+)
+
+// String returns the name Microsoft uses for the architecture.
+func (a Arch) String() string {
+	switch a {
+	case ArchX86:
+		return "x86"
+	case ArchX64:
+		return "x64"
+	case ArchARM64:
+		return "arm64"
+	}
+	return "unknown"
+}
+
+// Architecture returns the architecture of the runtime, read from the PE header of msedgewebview2.exe.
+// Returns ArchUnknown and an error if the binary could not be read.
+func (i *Info) Architecture() (Arch, error) {
+	return peArchitecture(filepath.Join(i.Location, i.Version, runtimeExecutable))
+}
+
+// peArchitecture returns the architecture of the given PE binary.
+func peArchitecture(path string) (Arch, error) {
+	file, err := pe.Open(path)
+	if err != nil {
+		return ArchUnknown, err
+	}
+	defer file.Close()
+	return machineArchitecture(file.Machine)
+}
+
+// verifyBitness checks that the given PE binary has the given process architecture.
+// Returns false and an error describing the mismatch if the architectures differ.
+func verifyBitness(path string, processArch Arch) (bool, error) {
+	binaryArch, err := peArchitecture(path)
+	if err != nil {
+		return false, fmt.Errorf("unable to read %s: %w", path, err)
+	}
+	if binaryArch != processArch {
+		return false, fmt.Errorf("%s is %s but the process is %s", path, binaryArch, processArch)
+	}
+	return true, nil
+}
+
+// machineArchitecture converts a PE machine type to an Arch.
+func machineArchitecture(machine uint16) (Arch, error) {
+	switch machine {
+	case pe.IMAGE_FILE_MACHINE_I386:
+		return ArchX86, nil
+	case pe.IMAGE_FILE_MACHINE_AMD64:
+		return ArchX64, nil
+	case pe.IMAGE_FILE_MACHINE_ARM64:
+		return ArchARM64, nil
+	}
+	return ArchUnknown, fmt.Errorf("unsupported machine type 0x%04x", machine)
+}
+
+// processArchitecture returns the architecture of the current process.
+func processArchitecture() Arch {
+	return goarchArchitecture(runtime.GOARCH)
+}
+
+// goarchArchitecture converts a GOARCH value to an Arch.
+func goarchArchitecture(goarch string) Arch {
+	switch goarch {
+	case "386":
+		return ArchX86
+	case "amd64":
+		return ArchX64
+	case "arm64":
+		return ArchARM64
+	}
+	return ArchUnknown
+}
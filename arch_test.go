@@ -0,0 +1,155 @@
+package webview2runtime
+
+import (
+	"bytes"
+	"debug/pe"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writePE writes a minimal PE binary for the given machine type to path.
+func writePE(t *testing.T, path string, machine uint16) {
+	t.Helper()
+	var buffer bytes.Buffer
+	dos := make([]byte, 0x40)
+	copy(dos, "MZ")
+	binary.LittleEndian.PutUint32(dos[0x3c:], uint32(len(dos)))
+	buffer.Write(dos)
+	buffer.WriteString("PE\x00\x00")
+	binary.Write(&buffer, binary.LittleEndian, pe.FileHeader{Machine: machine})
+	// debug/pe reads past the end of the headers
+	buffer.Write(make([]byte, 0x40))
+
+	err := os.MkdirAll(filepath.Dir(path), 0o700)
+	if err == nil {
+		err = os.WriteFile(path, buffer.Bytes(), 0o600)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// peSamples are PE machine types and the architectures they are read as.
+var peSamples = []struct {
+	machine uint16
+	arch    Arch
+}{
+	{pe.IMAGE_FILE_MACHINE_I386, ArchX86},
+	{pe.IMAGE_FILE_MACHINE_AMD64, ArchX64},
+	{pe.IMAGE_FILE_MACHINE_ARM64, ArchARM64},
+}
+
+func TestInfoArchitecture(t *testing.T) {
+	for _, sample := range peSamples {
+		t.Run(sample.arch.String(), func(t *testing.T) {
+			info := &Info{Location: t.TempDir(), Version: "120.0.2210.91"}
+			writePE(t, filepath.Join(info.Location, info.Version, runtimeExecutable), sample.machine)
+
+			arch, err := info.Architecture()
+			if err != nil {
+				t.Fatalf("Architecture() error = %v", err)
+			}
+			if arch != sample.arch {
+				t.Errorf("Architecture() = %s, want %s", arch, sample.arch)
+			}
+		})
+	}
+}
+
+func TestInfoArchitectureErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		write func(path string) error
+	}{
+		{"missing binary", func(string) error { return nil }},
+		{"not a PE binary", func(path string) error {
+			err := os.MkdirAll(filepath.Dir(path), 0o700)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(path, []byte("not a binary"), 0o600)
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			info := &Info{Location: t.TempDir(), Version: "120.0.2210.91"}
+			err := test.write(filepath.Join(info.Location, info.Version, runtimeExecutable))
+			if err != nil {
+				t.Fatal(err)
+			}
+			arch, err := info.Architecture()
+			if err == nil || arch != ArchUnknown {
+				t.Errorf("Architecture() = %s, %v, want ArchUnknown and an error", arch, err)
+			}
+		})
+	}
+
+	t.Run("unsupported machine", func(t *testing.T) {
+		info := &Info{Location: t.TempDir(), Version: "120.0.2210.91"}
+		writePE(t, filepath.Join(info.Location, info.Version, runtimeExecutable), pe.IMAGE_FILE_MACHINE_ARMNT)
+		arch, err := info.Architecture()
+		if err == nil || arch != ArchUnknown {
+			t.Errorf("Architecture() = %s, %v, want ArchUnknown and an error", arch, err)
+		}
+	})
+}
+
+func TestGoarchArchitecture(t *testing.T) {
+	tests := []struct {
+		goarch string
+		arch   Arch
+	}{
+		{"386", ArchX86},
+		{"amd64", ArchX64},
+		{"arm64", ArchARM64},
+		{"arm", ArchUnknown},
+	}
+	for _, test := range tests {
+		if got := goarchArchitecture(test.goarch); got != test.arch {
+			t.Errorf("goarchArchitecture(%q) = %s, want %s", test.goarch, got, test.arch)
+		}
+	}
+}
+
+func TestArchString(t *testing.T) {
+	tests := []struct {
+		arch Arch
+		want string
+	}{
+		{ArchX86, "x86"},
+		{ArchX64, "x64"},
+		{ArchARM64, "arm64"},
+		{ArchUnknown, "unknown"},
+	}
+	for _, test := range tests {
+		if got := test.arch.String(); got != test.want {
+			t.Errorf("Arch(%d).String() = %q, want %q", int(test.arch), got, test.want)
+		}
+	}
+}
+
+func TestVerifyBitness(t *testing.T) {
+	for _, sample := range peSamples {
+		for _, process := range []Arch{ArchX86, ArchX64, ArchARM64} {
+			t.Run(sample.arch.String()+" loader in "+process.String()+" process", func(t *testing.T) {
+				path := filepath.Join(t.TempDir(), "WebView2Loader.dll")
+				writePE(t, path, sample.machine)
+
+				ok, err := verifyBitness(path, process)
+				want := sample.arch == process
+				if ok != want || (err == nil) != want {
+					t.Errorf("verifyBitness() = %t, %v, want %t", ok, err, want)
+				}
+			})
+		}
+	}
+}
+
+func TestVerifyBitnessMissingLoader(t *testing.T) {
+	ok, err := verifyBitness(filepath.Join(t.TempDir(), "WebView2Loader.dll"), processArchitecture())
+	if ok || err == nil {
+		t.Errorf("verifyBitness() = %t, %v, want false and an error", ok, err)
+	}
+}
@@ -0,0 +1,29 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"golang.org/x/sys/windows"
+)
+
+// nativeArchitecture returns the architecture of the operating system, which may differ from the
+// process architecture, eg an x86 process emulated on arm64.
+func nativeArchitecture() Arch {
+	var processMachine, nativeMachine uint16
+	err := windows.IsWow64Process2(windows.CurrentProcess(), &processMachine, &nativeMachine)
+	if err == nil {
+		arch, err := machineArchitecture(nativeMachine)
+		if err == nil {
+			return arch
+		}
+	}
+
+	// IsWow64Process2 is not available before Windows 10 1511, which does not run on arm64
+	var wow64 bool
+	err = windows.IsWow64Process(windows.CurrentProcess(), &wow64)
+	if err == nil && wow64 {
+		return ArchX64
+	}
+	return processArchitecture()
+}
@@ -0,0 +1,53 @@
+package webview2runtime
+
+// InstallEventType is the kind of an InstallEvent.
+type InstallEventType int
+
+const (
+	// EventDownloadStarted is sent when the bootstrapper download starts.
+	EventDownloadStarted InstallEventType = iota
+	// EventDownloadProgress is sent as the bootstrapper downloads, with Downloaded and Total set.
+	EventDownloadProgress
+	// EventInstallerStarted is sent when the installer is started.
+	EventInstallerStarted
+	// EventInstallerExited is sent when the installer exits, with Result set.
+	EventInstallerExited
+	// EventVerified is sent once the installed runtime has been verified, with Version set.
+	EventVerified
+	// EventDone is the last event sent, with Result and Err set to the outcome of the install.
+	EventDone
+)
+
+// String returns the name of the event type.
+func (t InstallEventType) String() string {
+	switch t {
+	case EventDownloadStarted:
+		return "download started"
+	case EventDownloadProgress:
+		return "download progress"
+	case EventInstallerStarted:
+		return "installer started"
+	case EventInstallerExited:
+		return "installer exited"
+	case EventVerified:
+		return "verified"
+	case EventDone:
+		return "done"
+	}
+	return "unknown"
+}
+
+// InstallEvent reports the progress of an install started by StartInstall.
+type InstallEvent struct {
+	Type InstallEventType
+	// Downloaded and Total are the bytes downloaded so far and the size of the download.
+	// Total is -1 if the size is unknown.
+	Downloaded int64
+	Total      int64
+	// Version is the installed version of the runtime.
+	Version string
+	// Result is the result of the install so far.
+	Result *InstallResult
+	// Err is the error the install failed with, if it did.
+	Err error
+}
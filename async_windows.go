@@ -0,0 +1,67 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+)
+
+// installEventBuffer is the capacity of the channel returned by StartInstall.
+const installEventBuffer = 64
+
+// StartInstall is the same as InstallUsingBootstrapperWithResult but runs the install in the background,
+// sending its progress on the returned channel so GUI apps can drive their own progress UI.
+// The channel is closed after the EventDone event. It must be read until it is closed, although
+// EventDownloadProgress events are dropped rather than delaying the install if it is full.
+// Any DownloadProgress or PhaseChanged callbacks in the options are still called. The options may be nil.
+// Returns an error, and no channel, if the options are invalid.
+func StartInstall(ctx context.Context, options *InstallOptions) (<-chan InstallEvent, error) {
+	err := options.validate()
+	if err != nil {
+		return nil, err
+	}
+	events := make(chan InstallEvent, installEventBuffer)
+	var copied InstallOptions
+	if options != nil {
+		copied = *options
+	}
+	copied = sendEventsTo(copied, events)
+
+	go func() {
+		defer close(events)
+		result, err := InstallUsingBootstrapperWithResult(ctx, &copied)
+		events <- InstallEvent{Type: EventDone, Result: result, Err: err}
+	}()
+	return events, nil
+}
+
+// sendEventsTo returns a copy of the options that also sends the progress of the install to events.
+func sendEventsTo(options InstallOptions, events chan<- InstallEvent) InstallOptions {
+	downloadProgress := options.DownloadProgress
+	options.DownloadProgress = func(downloaded int64, total int64) {
+		select {
+		case events <- InstallEvent{Type: EventDownloadProgress, Downloaded: downloaded, Total: total}:
+		default:
+		}
+		if downloadProgress != nil {
+			downloadProgress(downloaded, total)
+		}
+	}
+	phaseChanged := options.PhaseChanged
+	options.PhaseChanged = func(phase Phase) {
+		switch phase {
+		case PhaseDownloading:
+			events <- InstallEvent{Type: EventDownloadStarted}
+		case PhaseInstalling:
+			events <- InstallEvent{Type: EventInstallerStarted}
+		}
+		if phaseChanged != nil {
+			phaseChanged(phase)
+		}
+	}
+	options.installEvent = func(event InstallEvent) {
+		events <- event
+	}
+	return options
+}
@@ -0,0 +1,17 @@
+package webview2runtime
+
+import (
+	"time"
+)
+
+// CachePolicy configures the cache of downloaded installers.
+// Installers are stored as `<version>-<sha256>.exe` and their hash is checked before they are reused.
+// Cache failures never fail an install: the installer is downloaded as if there was no cache.
+type CachePolicy struct {
+	// Dir is the folder the installers are kept in. It is created if needed.
+	Dir string
+	// MaxAge, if set, is how long an installer is reused for before it is downloaded again.
+	MaxAge time.Duration
+	// MaxSize, if set, is the most bytes kept in the cache. The oldest installers are removed first.
+	MaxSize int64
+}
@@ -0,0 +1,164 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// cachedInstaller is an installer in the cache.
+type cachedInstaller struct {
+	path    string
+	sha256  string
+	size    int64
+	modTime time.Time
+}
+
+// entries returns the installers in the cache, newest first.
+func (c *CachePolicy) entries() ([]cachedInstaller, error) {
+	files, err := os.ReadDir(c.Dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entries []cachedInstaller
+	for _, file := range files {
+		name := file.Name()
+		dash := strings.LastIndex(name, "-")
+		if file.IsDir() || filepath.Ext(name) != ".exe" || dash < 0 {
+			continue
+		}
+		info, err := file.Info()
+		if err != nil {
+			continue
+		}
+		entries = append(entries, cachedInstaller{
+			path:    filepath.Join(c.Dir, name),
+			sha256:  strings.TrimSuffix(name[dash+1:], ".exe"),
+			size:    info.Size(),
+			modTime: info.ModTime(),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].modTime.After(entries[j].modTime)
+	})
+	return entries, nil
+}
+
+func (c *CachePolicy) expired(entry cachedInstaller) bool {
+	return c.MaxAge > 0 && time.Since(entry.modTime) > c.MaxAge
+}
+
+// copyTo copies the newest valid installer in the cache to the given path.
+// Installers that have expired, or whose hash does not match their name, are removed.
+// If the SHA256 option is set, only an installer with that hash is used.
+// Returns false if there is no usable installer in the cache.
+func (c *CachePolicy) copyTo(path string, options *InstallOptions) bool {
+	entries, err := c.entries()
+	if err != nil {
+		logEvent("unable to read installer cache", "dir", c.Dir, "error", err)
+		return false
+	}
+	for _, entry := range entries {
+		if c.expired(entry) {
+			logEvent("removing expired installer", "path", entry.path)
+			_ = os.Remove(entry.path)
+			continue
+		}
+		if options.SHA256 != "" && !strings.EqualFold(entry.sha256, options.SHA256) {
+			continue
+		}
+		actual, err := fileSHA256(entry.path)
+		if err != nil || !strings.EqualFold(actual, entry.sha256) {
+			logEvent("removing corrupt installer", "path", entry.path, "error", err)
+			_ = os.Remove(entry.path)
+			continue
+		}
+		err = copyFile(entry.path, path)
+		if err != nil {
+			logEvent("unable to copy cached installer", "path", entry.path, "error", err)
+			return false
+		}
+		logEvent("using cached installer", "path", entry.path)
+		return true
+	}
+	return false
+}
+
+// store adds the downloaded installer to the cache, if it passes the installer checks, then evicts
+// installers that have expired or no longer fit.
+func (c *CachePolicy) store(installer string, options *InstallOptions) {
+	err := options.checkInstaller(installer)
+	if err != nil {
+		return
+	}
+	err = c.add(installer)
+	if err != nil {
+		logEvent("unable to cache installer", "path", installer, "error", err)
+	}
+	c.evict()
+}
+
+func (c *CachePolicy) add(installer string) error {
+	hash, err := fileSHA256(installer)
+	if err != nil {
+		return err
+	}
+	version, err := getFileVersion(installer)
+	if err != nil || version == "" {
+		version = "unknown"
+	}
+	err = os.MkdirAll(c.Dir, 0755)
+	if err != nil {
+		return err
+	}
+	path := filepath.Join(c.Dir, fmt.Sprintf("%s-%s.exe", version, hash))
+	// Copy to a temp file first so a partially written installer is never reused
+	temp := path + ".tmp"
+	err = copyFile(installer, temp)
+	if err == nil {
+		_ = os.Remove(path)
+		err = os.Rename(temp, path)
+	}
+	if err != nil {
+		_ = os.Remove(temp)
+		return err
+	}
+	logEvent("cached installer", "path", path)
+	return nil
+}
+
+// evict removes expired installers, then the oldest installers until the cache fits in MaxSize.
+func (c *CachePolicy) evict() {
+	entries, err := c.entries()
+	if err != nil {
+		return
+	}
+	var total int64
+	for _, entry := range entries {
+		if c.expired(entry) || (c.MaxSize > 0 && total+entry.size > c.MaxSize) {
+			logEvent("evicting cached installer", "path", entry.path)
+			_ = os.Remove(entry.path)
+			continue
+		}
+		total += entry.size
+	}
+}
+
+// copyFile copies the file at src to dst, replacing dst if it exists.
+func copyFile(src string, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	return writeInstaller(dst, in)
+}
@@ -0,0 +1,235 @@
+package webview2runtime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Channel is the release channel of a runtime.
+type Channel int
+
+const (
+	// ChannelStable is the evergreen webview2 runtime.
+	ChannelStable Channel = iota
+	// ChannelBeta is Microsoft Edge Beta.
+	ChannelBeta
+	// ChannelDev is Microsoft Edge Dev.
+	ChannelDev
+	// ChannelCanary is Microsoft Edge Canary.
+	ChannelCanary
+)
+
+// String returns the name of the channel.
+func (c Channel) String() string {
+	switch c {
+	case ChannelStable:
+		return "stable"
+	case ChannelBeta:
+		return "beta"
+	case ChannelDev:
+		return "dev"
+	case ChannelCanary:
+		return "canary"
+	}
+	return "unknown"
+}
+
+// previewChannel describes where a preview channel of Edge is registered and installed.
+type previewChannel struct {
+	channel Channel
+	// guid is the EdgeUpdate client ID of the channel.
+	guid string
+	// folder is the installation folder relative to the base folder in the environment variable env.
+	folder string
+	env    []string
+	scope  Scope
+}
+
+// previewChannels are the preview channels of Edge, in order of stability.
+// Canary can only be installed per-user.
+var previewChannels = []previewChannel{
+	{
+		channel: ChannelBeta,
+		guid:    `{2CD8A007-E189-409D-A2C8-9AF4EF3C72AA}`,
+		folder:  `Microsoft\Edge Beta\Application`,
+		env:     []string{"ProgramFiles(x86)", "ProgramFiles"},
+		scope:   ScopeMachine,
+	},
+	{
+		channel: ChannelDev,
+		guid:    `{0D50BFEC-CD6A-4F9A-964C-C7416E3ACB10}`,
+		folder:  `Microsoft\Edge Dev\Application`,
+		env:     []string{"ProgramFiles(x86)", "ProgramFiles"},
+		scope:   ScopeMachine,
+	},
+	{
+		channel: ChannelCanary,
+		guid:    `{65C35B14-6C1D-4122-AC46-7148CC9D6497}`,
+		folder:  `Microsoft\Edge SxS\Application`,
+		env:     []string{"LOCALAPPDATA"},
+		scope:   ScopeUser,
+	},
+}
+
+// Channels returns the stable runtime and every preview channel of Edge that is installed.
+// Preview channels are found using their EdgeUpdate registrations, or their installation
+// folders if they are not registered.
+func (d *Detector) Channels() ([]Info, error) {
+	var result []Info
+	stable, err := d.Installation()
+	if err != nil {
+		return nil, err
+	}
+	if stable != nil {
+		stable.Channel = ChannelStable
+		result = append(result, *stable)
+	}
+	previews, err := d.previewChannels()
+	if err != nil {
+		return nil, err
+	}
+	return append(result, previews...), nil
+}
+
+// ListInstallations returns every registration of the runtime followed by the preview channels of Edge.
+func (d *Detector) ListInstallations() ([]Info, error) {
+	registrations, err := d.Registrations()
+	if err != nil {
+		return nil, err
+	}
+	var result []Info
+	for _, registration := range registrations {
+		result = append(result, registration.Info)
+	}
+	previews, err := d.previewChannels()
+	if err != nil {
+		return nil, err
+	}
+	return append(result, previews...), nil
+}
+
+// previewChannels returns the installed preview channels of Edge, in order of stability.
+func (d *Detector) previewChannels() ([]Info, error) {
+	var result []Info
+	for _, channel := range previewChannels {
+		info, err := d.previewChannel(channel)
+		if err != nil {
+			return nil, err
+		}
+		if info != nil {
+			result = append(result, *info)
+		}
+	}
+	return result, nil
+}
+
+// previewChannel returns the installation of the given preview channel, or nil if it is not installed.
+func (d *Detector) previewChannel(channel previewChannel) (*Info, error) {
+	for _, key := range clientKeys(channel.guid, d.architecture()) {
+		info, err := d.registeredInfo(key, channel.guid)
+		if err != nil {
+			return nil, err
+		}
+		if info == nil {
+			continue
+		}
+		info.Channel = channel.channel
+		if info.Location == "" {
+			info.Location = channel.installFolder()
+		}
+		return info, nil
+	}
+
+	folder := channel.installFolder()
+	if folder == "" {
+		return nil, nil
+	}
+	version := newestVersionFolder(folder)
+	if version == "" {
+		return nil, nil
+	}
+	return &Info{
+		Location:        folder,
+		Version:         version,
+		Scope:           channel.scope,
+		Channel:         channel.channel,
+		DetectionMethod: DetectionFolder,
+	}, nil
+}
+
+// installFolder returns the installation folder of the channel, or a blank string if it does not exist.
+func (c previewChannel) installFolder() string {
+	for _, env := range c.env {
+		base := os.Getenv(env)
+		if base == "" {
+			continue
+		}
+		folder := filepath.Join(base, c.folder)
+		if exists(folder) {
+			return folder
+		}
+	}
+	return ""
+}
+
+// newestVersionFolder returns the newest version that has a complete browser in the given
+// Application folder. Edge installs each version into a folder named after the version.
+func newestVersionFolder(folder string) string {
+	entries, err := os.ReadDir(folder)
+	if err != nil {
+		return ""
+	}
+	var newest string
+	for _, entry := range entries {
+		if !entry.IsDir() || !exists(filepath.Join(folder, entry.Name(), "msedge.dll")) {
+			continue
+		}
+		_, err := parseVersion(entry.Name())
+		if err != nil {
+			continue
+		}
+		if newest == "" || CompareVersions(entry.Name(), newest) > 0 {
+			newest = entry.Name()
+		}
+	}
+	return newest
+}
+
+// channelGUID returns the EdgeUpdate client ID that installs the given channel.
+// Returns an error if the channel is not known.
+func channelGUID(channel Channel) (string, error) {
+	if channel == ChannelStable {
+		return clientGUID, nil
+	}
+	for _, preview := range previewChannels {
+		if preview.channel == channel {
+			return preview.guid, nil
+		}
+	}
+	return "", fmt.Errorf("invalid target channel: %d", int(channel))
+}
+
+// channelInstallation returns the installation of the given channel, or nil if it is not installed.
+// The stable runtime is found using Detect, preview channels using the registrations of their
+// EdgeUpdate client ID.
+func (d *Detector) channelInstallation(channel Channel) (*Info, error) {
+	if channel == ChannelStable {
+		return d.Detect()
+	}
+	guid, err := channelGUID(channel)
+	if err != nil {
+		return nil, err
+	}
+	for _, key := range clientKeys(guid, d.architecture()) {
+		info, err := d.registeredInfo(key, guid)
+		if err != nil {
+			return nil, err
+		}
+		if info != nil {
+			info.Channel = channel
+			return info, nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,20 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+// DetectChannels returns the stable runtime and every preview channel of Edge that is installed,
+// in order of stability. WebView2 uses a preview channel when the stable runtime is missing,
+// so apps can decide whether the preview channels found are acceptable.
+// Returns an error if the registry could not be read.
+func DetectChannels() ([]Info, error) {
+	return defaultDetector.Channels()
+}
+
+// ListInstallations returns every installation that WebView2 can use: each registration of the
+// runtime, per-machine and per-user, followed by the preview channels of Edge.
+// Useful for diagnostics and support tooling.
+// Returns an error if the registry could not be read.
+func ListInstallations() ([]Info, error) {
+	return defaultDetector.ListInstallations()
+}
@@ -0,0 +1,46 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"errors"
+	"golang.org/x/sys/windows"
+	"os"
+	"path/filepath"
+)
+
+// artifactPatterns match the installers this package writes to the temp directory, including
+// the fixed name used by earlier versions.
+var artifactPatterns = []string{
+	defaultInstallerFilename,
+	installerFilenamePattern,
+}
+
+// CleanupArtifacts removes installers left in the temp directory by previous runs of this package,
+// eg after a crash or when using CleanupNever. Installers that are still running are skipped.
+// Installers written using a custom InstallerFilename are not removed.
+func CleanupArtifacts() error {
+	var result error
+	for _, pattern := range artifactPatterns {
+		matches, err := filepath.Glob(filepath.Join(os.TempDir(), pattern))
+		if err != nil {
+			return err
+		}
+		for _, file := range matches {
+			err := os.Remove(file)
+			if err == nil || os.IsNotExist(err) || isInUse(err) {
+				continue
+			}
+			if result == nil {
+				result = err
+			}
+		}
+	}
+	return result
+}
+
+// isInUse returns true if the error is because the file is open or running.
+func isInUse(err error) bool {
+	return errors.Is(err, windows.ERROR_SHARING_VIOLATION) || errors.Is(err, windows.ERROR_ACCESS_DENIED)
+}
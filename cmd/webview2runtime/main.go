@@ -0,0 +1,163 @@
+//go:build windows
+// +build windows
+
+// Command webview2runtime detects, installs, verifies and uninstalls the WebView2 runtime.
+// It is intended for installers, eg NSIS scripts and WiX custom actions, and provisioning scripts:
+//
+//	webview2runtime detect
+//	webview2runtime install [-silent] [-embedded | -standalone <path>] [-min-version <version>]
+//	webview2runtime verify [-min-version <version>] [-format text|json]
+//	webview2runtime uninstall
+//
+// It exits with 0 on success, 3010 if a reboot is needed to finish the install and 1 on failure.
+// verify exits with the codes of webview2runtime.RunDiagnostics.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"github.com/leaanthony/webview2runtime"
+	"os"
+	"os/signal"
+)
+
+// Exit codes. exitRebootRequired matches ERROR_SUCCESS_REBOOT_REQUIRED so MSI based installers understand it.
+const (
+	exitSuccess        = 0
+	exitFailure        = 1
+	exitUsage          = 2
+	exitRebootRequired = 3010
+)
+
+const usage = `usage: webview2runtime <command> [flags]
+
+commands:
+  detect     print the installed runtime as JSON
+  install    install the runtime
+  verify     check the runtime is installed
+  uninstall  uninstall the runtime
+`
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	if len(args) == 0 {
+		fmt.Fprint(os.Stderr, usage)
+		return exitUsage
+	}
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	switch args[0] {
+	case "detect":
+		return detect(args[1:])
+	case "install":
+		return install(ctx, args[1:])
+	case "verify":
+		return webview2runtime.RunDiagnostics(args[1:], os.Stdout)
+	case "uninstall":
+		return uninstall(ctx, args[1:])
+	}
+	fmt.Fprintf(os.Stderr, "unknown command: %s\n%s", args[0], usage)
+	return exitUsage
+}
+
+func detect(args []string) int {
+	flags := flag.NewFlagSet("detect", flag.ContinueOnError)
+	effective := flags.Bool("effective", false, "report the runtime this process would use, honouring the WEBVIEW2_* environment variables")
+	if flags.Parse(args) != nil {
+		return exitUsage
+	}
+	detectRuntime := webview2runtime.Detect
+	if *effective {
+		detectRuntime = webview2runtime.DetectEffective
+	}
+	info, err := detectRuntime()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitFailure
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	err = encoder.Encode(info)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitFailure
+	}
+	return exitSuccess
+}
+
+func install(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("install", flag.ContinueOnError)
+	silent := flags.Bool("silent", false, "run the installer without any UI")
+	embedded := flags.Bool("embedded", false, "use the embedded bootstrapper rather than downloading it")
+	standalone := flags.String("standalone", "", "the path of a standalone installer to run")
+	minVersion := flags.String("min-version", "", "skip the install if this version or newer is installed")
+	if flags.Parse(args) != nil {
+		return exitUsage
+	}
+	if *embedded && *standalone != "" {
+		fmt.Fprintln(os.Stderr, "-embedded and -standalone cannot be used together")
+		return exitUsage
+	}
+
+	if *minVersion != "" {
+		status, err := webview2runtime.Status(*minVersion)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return exitFailure
+		}
+		if status == webview2runtime.StatusInstalledOK || status == webview2runtime.StatusInstalledNewer {
+			fmt.Printf("WebView2 runtime %s is already installed\n", webview2runtime.GetInstalledVersion())
+			return exitSuccess
+		}
+	}
+
+	options := &webview2runtime.InstallOptions{Silent: *silent}
+	var result *webview2runtime.InstallResult
+	var err error
+	switch {
+	case *standalone != "":
+		result, err = webview2runtime.InstallUsingStandaloneInstallerWithContext(ctx, *standalone, options)
+	case *embedded:
+		result, err = webview2runtime.InstallUsingEmbeddedBootstrapperWithResult(ctx, options)
+	default:
+		result, err = webview2runtime.InstallUsingBootstrapperWithResult(ctx, options)
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitFailure
+	}
+	fmt.Printf("WebView2 runtime %s installed (%s)\n", webview2runtime.GetInstalledVersion(), result.Reason)
+	if result.RebootRequired {
+		return exitRebootRequired
+	}
+	return exitSuccess
+}
+
+func uninstall(ctx context.Context, args []string) int {
+	flags := flag.NewFlagSet("uninstall", flag.ContinueOnError)
+	if flags.Parse(args) != nil {
+		return exitUsage
+	}
+	info, err := webview2runtime.GetInstallation()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitFailure
+	}
+	if info == nil {
+		fmt.Println("WebView2 runtime is not installed")
+		return exitSuccess
+	}
+	err = info.Uninstall(ctx)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return exitFailure
+	}
+	fmt.Printf("WebView2 runtime %s uninstalled\n", info.Version)
+	return exitSuccess
+}
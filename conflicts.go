@@ -0,0 +1,47 @@
+package webview2runtime
+
+import (
+	"fmt"
+)
+
+// Registration is an installation of the webview2 runtime found in the registry.
+type Registration struct {
+	// Key is the registry key the installation is registered under.
+	Key  string
+	Info Info
+}
+
+// Conflict describes two registrations of the runtime that disagree with each other.
+type Conflict struct {
+	Description string
+	Entries     []Registration
+}
+
+// DetectConflicts is the same as the package level DetectConflicts but uses this Detector.
+func (d *Detector) DetectConflicts() ([]Conflict, error) {
+	registrations, err := d.Registrations()
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []Conflict
+	for i := 0; i < len(registrations); i++ {
+		for j := i + 1; j < len(registrations); j++ {
+			a, b := registrations[i], registrations[j]
+			var description string
+			switch {
+			case a.Info.Version != b.Info.Version:
+				description = fmt.Sprintf("version %s registered under %s differs from version %s registered under %s", a.Info.Version, a.Key, b.Info.Version, b.Key)
+			case a.Info.Location != b.Info.Location:
+				description = fmt.Sprintf("location '%s' registered under %s differs from location '%s' registered under %s", a.Info.Location, a.Key, b.Info.Location, b.Key)
+			default:
+				continue
+			}
+			conflicts = append(conflicts, Conflict{
+				Description: description,
+				Entries:     []Registration{a, b},
+			})
+		}
+	}
+	return conflicts, nil
+}
@@ -0,0 +1,87 @@
+package webview2runtime
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDetectorDetectConflicts(t *testing.T) {
+	const location = `C:\Program Files (x86)\Microsoft\EdgeWebView\Application`
+	tests := []struct {
+		name     string
+		snapshot RegistrySnapshot
+		// keys are the pairs of keys expected to conflict, in order.
+		keys [][2]string
+	}{
+		{name: "not installed", snapshot: RegistrySnapshot{}},
+		{name: "single registration", snapshot: runtimeRegistry("120.0.2210.91")},
+		{
+			name: "matching registrations",
+			snapshot: RegistrySnapshot{
+				machineRuntimeKey:                  {"pv": "120.0.2210.91", "location": location},
+				currentUserClientsKey + clientGUID: {"pv": "120.0.2210.91", "location": location},
+			},
+		},
+		{
+			name: "different versions",
+			snapshot: RegistrySnapshot{
+				machineRuntimeKey:                  {"pv": "120.0.2210.91", "location": location},
+				currentUserClientsKey + clientGUID: {"pv": "119.0.2151.97", "location": location},
+			},
+			keys: [][2]string{{machineRuntimeKey, currentUserClientsKey + clientGUID}},
+		},
+		{
+			name: "different locations",
+			snapshot: RegistrySnapshot{
+				machineRuntimeKey:              {"pv": "120.0.2210.91", "location": location},
+				machineClientsKey + clientGUID: {"pv": "120.0.2210.91", "location": `C:\Program Files\Microsoft\EdgeWebView\Application`},
+			},
+			keys: [][2]string{{machineRuntimeKey, machineClientsKey + clientGUID}},
+		},
+		{
+			name: "every registration differs",
+			snapshot: RegistrySnapshot{
+				machineRuntimeKey:                  {"pv": "120.0.2210.91"},
+				machineClientsKey + clientGUID:     {"pv": "119.0.2151.97"},
+				currentUserClientsKey + clientGUID: {"pv": "118.0.2088.76"},
+			},
+			keys: [][2]string{
+				{machineRuntimeKey, machineClientsKey + clientGUID},
+				{machineRuntimeKey, currentUserClientsKey + clientGUID},
+				{machineClientsKey + clientGUID, currentUserClientsKey + clientGUID},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			registry := &recordingRegistry{snapshot: test.snapshot}
+			detector := &Detector{Registry: registry, Loader: noLoader, Architecture: ArchX64}
+			conflicts, err := detector.DetectConflicts()
+			if err != nil {
+				t.Fatalf("DetectConflicts() error = %v", err)
+			}
+			var keys [][2]string
+			for _, conflict := range conflicts {
+				if len(conflict.Entries) != 2 || conflict.Description == "" {
+					t.Fatalf("DetectConflicts() conflict = %+v, want a description and two entries", conflict)
+				}
+				keys = append(keys, [2]string{conflict.Entries[0].Key, conflict.Entries[1].Key})
+			}
+			if !reflect.DeepEqual(keys, test.keys) {
+				t.Errorf("DetectConflicts() keys = %q, want %q", keys, test.keys)
+			}
+			if len(registry.keys) == 0 {
+				t.Error("DetectConflicts() read no registry keys")
+			}
+		})
+	}
+}
+
+func TestDetectorDetectConflictsRegistryError(t *testing.T) {
+	detector := &Detector{Registry: failingRegistry{}, Loader: noLoader, Architecture: ArchX64}
+	conflicts, err := detector.DetectConflicts()
+	if !errors.Is(err, ErrRegistryAccess) || conflicts != nil {
+		t.Errorf("DetectConflicts() = %v, %v, want ErrRegistryAccess", conflicts, err)
+	}
+}
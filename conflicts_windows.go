@@ -0,0 +1,13 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+// DetectConflicts checks every registry key the runtime may be registered under and
+// reports each pair of registrations that disagree on version or location.
+// Conflicting registrations usually indicate a messy install and can cause the
+// wrong runtime to be selected.
+// Returns an error if the registry could not be read.
+func DetectConflicts() ([]Conflict, error) {
+	return defaultDetector.DetectConflicts()
+}
@@ -0,0 +1,110 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"sync"
+	"time"
+)
+
+// detectionCache holds the results of GetInstalledVersion and Detect while caching is enabled.
+var detectionCache struct {
+	lock sync.Mutex
+	ttl  time.Duration
+	// generation changes each time the cache is invalidated, so that a detection that was running
+	// at the time does not store its result.
+	generation uint64
+
+	version   string
+	versionAt time.Time
+	info      *Info
+	infoAt    time.Time
+}
+
+// SetDetectionCacheTTL caches the results of GetInstalledVersion and Detect for the given duration,
+// for apps that check the runtime in hot paths, eg each time a window is created. Passing 0 disables
+// caching, which is the default. The cache is invalidated whenever this package runs an installer,
+// update or uninstaller, and whenever WatchInstallation sees the installation change.
+// Use InvalidateDetectionCache to invalidate it after other changes.
+func SetDetectionCacheTTL(ttl time.Duration) {
+	detectionCache.lock.Lock()
+	defer detectionCache.lock.Unlock()
+	detectionCache.ttl = ttl
+	invalidateDetectionCache()
+}
+
+// InvalidateDetectionCache discards any cached detection results, so the next call to
+// GetInstalledVersion or Detect checks the installation again.
+func InvalidateDetectionCache() {
+	detectionCache.lock.Lock()
+	defer detectionCache.lock.Unlock()
+	invalidateDetectionCache()
+}
+
+// invalidateDetectionCache discards the cached results. The cache lock must be held.
+func invalidateDetectionCache() {
+	detectionCache.generation++
+	detectionCache.version = ""
+	detectionCache.versionAt = time.Time{}
+	detectionCache.info = nil
+	detectionCache.infoAt = time.Time{}
+}
+
+// cacheValid returns true if a result cached at the given time has not expired.
+// The cache lock must be held.
+func cacheValid(at time.Time) bool {
+	return detectionCache.ttl > 0 && !at.IsZero() && time.Since(at) < detectionCache.ttl
+}
+
+// cachedInstalledVersion returns the installed version, from the cache if it is enabled and valid.
+func cachedInstalledVersion() string {
+	detectionCache.lock.Lock()
+	if cacheValid(detectionCache.versionAt) {
+		defer detectionCache.lock.Unlock()
+		return detectionCache.version
+	}
+	generation := detectionCache.generation
+	detectionCache.lock.Unlock()
+
+	version := defaultDetector.InstalledVersion()
+
+	detectionCache.lock.Lock()
+	defer detectionCache.lock.Unlock()
+	if detectionCache.ttl > 0 && detectionCache.generation == generation {
+		detectionCache.version = version
+		detectionCache.versionAt = time.Now()
+	}
+	return version
+}
+
+// cachedDetect returns the installed runtime, from the cache if it is enabled and valid.
+// Failed detections are not cached. Returns true if the result came from the cache.
+func cachedDetect() (*Info, bool, error) {
+	detectionCache.lock.Lock()
+	if cacheValid(detectionCache.infoAt) {
+		defer detectionCache.lock.Unlock()
+		return copyInfo(detectionCache.info), true, nil
+	}
+	generation := detectionCache.generation
+	detectionCache.lock.Unlock()
+
+	info, err := defaultDetector.Detect()
+
+	detectionCache.lock.Lock()
+	defer detectionCache.lock.Unlock()
+	if err == nil && detectionCache.ttl > 0 && detectionCache.generation == generation {
+		detectionCache.info = copyInfo(info)
+		detectionCache.infoAt = time.Now()
+	}
+	return info, false, err
+}
+
+// copyInfo returns a copy of the installation, so callers cannot change the cached one.
+func copyInfo(info *Info) *Info {
+	if info == nil {
+		return nil
+	}
+	copied := *info
+	return &copied
+}
@@ -0,0 +1,207 @@
+package webview2runtime
+
+// RegistryReader provides access to registry values.
+type RegistryReader interface {
+	// ReadValues returns the values of the given key, eg `HKLM\SOFTWARE\...`.
+	// Integer values are returned in decimal and multi-string values are joined with newlines.
+	// Returns nil if the key does not exist.
+	ReadValues(key string) (map[string]string, error)
+}
+
+// Loader provides the WebView2Loader.dll functions used for detection.
+type Loader interface {
+	// AvailableBrowserVersion returns the version of the runtime the loader would use.
+	AvailableBrowserVersion() (string, error)
+	// CompareBrowserVersions returns -1, 0 or 1 if v1 is older than, the same as or newer than v2.
+	CompareBrowserVersions(v1 string, v2 string) (int, error)
+}
+
+// Detector detects installations of the webview2 runtime.
+// The package level functions use a Detector backed by the real registry and WebView2Loader.dll.
+// Tests may create a Detector with a RegistrySnapshot and a fake Loader to get reproducible results.
+type Detector struct {
+	Registry RegistryReader
+	Loader   Loader
+	// Architecture is the operating system architecture used to choose the registry keys to read.
+	// If ArchUnknown, the architecture of the running system is used.
+	Architecture Arch
+}
+
+// NewDetector returns a Detector that uses the system registry and WebView2Loader.dll.
+func NewDetector() *Detector {
+	return &Detector{
+		Registry: systemRegistry{},
+		Loader:   systemLoader{},
+	}
+}
+
+var defaultDetector = NewDetector()
+
+func (d *Detector) architecture() Arch {
+	if d.Architecture == ArchUnknown {
+		return nativeArchitecture()
+	}
+	return d.Architecture
+}
+
+// readValues reads the given key from the registry, wrapping any failure in a RegistryError.
+func (d *Detector) readValues(key string) (map[string]string, error) {
+	values, err := d.Registry.ReadValues(key)
+	if err != nil {
+		logEvent("registry read failed", "key", key, "error", err)
+		return nil, &RegistryError{Key: key, Err: err}
+	}
+	logEvent("registry read", "key", key, "values", len(values))
+	return values, nil
+}
+
+// DetectionMethod is the mechanism used to detect an installation.
+type DetectionMethod int
+
+const (
+	// DetectionNone means the installation was not found by automatic detection.
+	DetectionNone DetectionMethod = iota
+	// DetectionLoader means WebView2Loader.dll's GetAvailableCoreWebView2BrowserVersionString found the installation.
+	DetectionLoader
+	// DetectionRegistry means the EdgeUpdate registry keys found the installation.
+	DetectionRegistry
+	// DetectionFolder means the installation folder was found on disk without a registration.
+	DetectionFolder
+)
+
+// String returns the name of the detection method.
+func (d DetectionMethod) String() string {
+	switch d {
+	case DetectionLoader:
+		return "loader"
+	case DetectionRegistry:
+		return "registry"
+	case DetectionFolder:
+		return "folder"
+	}
+	return "none"
+}
+
+// InstalledVersion returns the installed version of the webview2 runtime, as found by Detect.
+// If there is no version installed, a blank string is returned.
+func (d *Detector) InstalledVersion() string {
+	info, err := d.Detect()
+	if err != nil || info == nil {
+		return ""
+	}
+	return info.Version
+}
+
+// compare compares the versions in pure Go, only asking the Loader if they cannot be parsed.
+// Returns -1, 0 or 1 if v1 is older than, the same as or newer than v2.
+func (d *Detector) compare(v1 string, v2 string) (int, error) {
+	result, err := compareVersions(stripChannel(v1), stripChannel(v2))
+	if err == nil {
+		return result, nil
+	}
+	return d.Loader.CompareBrowserVersions(v1, v2)
+}
+
+// IsOlderThan returns true if the given installation is older than the given required version.
+// Returns error if something goes wrong.
+func (d *Detector) IsOlderThan(info *Info, requiredVersion string) (bool, error) {
+	result, err := d.compare(info.Version, requiredVersion)
+	if err != nil {
+		return false, err
+	}
+	return result == -1, nil
+}
+
+// Registrations returns every registration of the runtime found in the registry.
+func (d *Detector) Registrations() ([]Registration, error) {
+	var registrations []Registration
+	for _, key := range runtimeKeys(d.architecture()) {
+		info, err := d.registeredInfo(key, clientGUID)
+		if err != nil {
+			return nil, err
+		}
+		if info == nil {
+			continue
+		}
+		registrations = append(registrations, Registration{Key: key, Info: *info})
+	}
+	return registrations, nil
+}
+
+// Installation returns the newest installation of the runtime registered in the registry.
+// Both per-machine (HKLM) and per-user (HKCU) registrations are checked.
+// Returns nil if no installation is registered.
+func (d *Detector) Installation() (*Info, error) {
+	registrations, err := d.Registrations()
+	if err != nil {
+		return nil, err
+	}
+	var result *Info
+	for i := range registrations {
+		info := &registrations[i].Info
+		if result != nil {
+			comparison, err := compareVersions(info.Version, result.Version)
+			if err != nil || comparison <= 0 {
+				continue
+			}
+		}
+		result = info
+	}
+	return result, nil
+}
+
+// Detect is the same as the package level Detect but uses this Detector.
+func (d *Detector) Detect() (*Info, error) {
+	registered, err := d.Installation()
+	if err != nil {
+		return nil, err
+	}
+	loaderVersion, loaderErr := d.Loader.AvailableBrowserVersion()
+	if loaderErr != nil || loaderVersion == "" {
+		return registered, nil
+	}
+
+	result := &Info{Version: loaderVersion}
+	if registered != nil {
+		if stripChannel(loaderVersion) == registered.Version {
+			*result = *registered
+			result.Version = loaderVersion
+		} else {
+			result.RegistryVersion = registered.Version
+			result.UpdatePolicy = registered.UpdatePolicy
+		}
+	}
+	result.DetectionMethod = DetectionLoader
+	return result, nil
+}
+
+// Status is the same as the package level Status but uses this Detector.
+func (d *Detector) Status(minVersion string) (RuntimeStatus, error) {
+	info, err := d.Detect()
+	if err != nil {
+		return StatusNotInstalled, err
+	}
+	return d.statusOf(info, minVersion)
+}
+
+// statusOf returns the status of the given installation compared to minVersion.
+// A nil installation is not installed.
+func (d *Detector) statusOf(info *Info, minVersion string) (RuntimeStatus, error) {
+	if info == nil || info.Version == "" {
+		return StatusNotInstalled, nil
+	}
+	if minVersion == "" {
+		return StatusInstalledOK, nil
+	}
+	result, err := d.compare(info.Version, minVersion)
+	if err != nil {
+		return StatusNotInstalled, err
+	}
+	switch result {
+	case -1:
+		return StatusInstalledTooOld, nil
+	case 0:
+		return StatusInstalledOK, nil
+	}
+	return StatusInstalledNewer, nil
+}
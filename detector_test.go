@@ -0,0 +1,102 @@
+package webview2runtime
+
+import (
+	"errors"
+	"testing"
+)
+
+// fakeLoader is a Loader that reports a fixed version.
+type fakeLoader struct {
+	version string
+	err     error
+}
+
+func (l fakeLoader) AvailableBrowserVersion() (string, error) {
+	return l.version, l.err
+}
+
+func (l fakeLoader) CompareBrowserVersions(v1 string, v2 string) (int, error) {
+	return 0, errors.New("loader comparison not available")
+}
+
+// noLoader is a Loader that finds nothing, as when WebView2Loader.dll is missing.
+var noLoader = fakeLoader{err: errors.New("loader not available")}
+
+// failingRegistry is a RegistryReader that fails every read.
+type failingRegistry struct{}
+
+func (failingRegistry) ReadValues(key string) (map[string]string, error) {
+	return nil, errors.New("access denied")
+}
+
+// machineRuntimeKey is where the runtime is registered per-machine on 64-bit Windows.
+const machineRuntimeKey = machineWOW64ClientsKey + clientGUID
+
+// runtimeRegistry returns a snapshot with the runtime registered per-machine at the given version.
+func runtimeRegistry(version string) RegistrySnapshot {
+	return RegistrySnapshot{
+		machineRuntimeKey: {"pv": version, "location": `C:\Program Files (x86)\Microsoft\EdgeWebView\Application`},
+	}
+}
+
+func TestDetectorStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		registry   RegistryReader
+		loader     Loader
+		minVersion string
+		want       RuntimeStatus
+		wantErr    bool
+	}{
+		{name: "not installed", registry: RegistrySnapshot{}, loader: noLoader, minVersion: "120.0.2210.91", want: StatusNotInstalled},
+		{name: "blank registered version", registry: runtimeRegistry(""), loader: noLoader, want: StatusNotInstalled},
+		{name: "installed without a minimum", registry: runtimeRegistry("90.0.818.66"), loader: noLoader, want: StatusInstalledOK},
+		{name: "same version", registry: runtimeRegistry("120.0.2210.91"), loader: noLoader, minVersion: "120.0.2210.91", want: StatusInstalledOK},
+		{name: "too old", registry: runtimeRegistry("90.0.818.66"), loader: noLoader, minVersion: "120.0.2210.91", want: StatusInstalledTooOld},
+		{name: "newer", registry: runtimeRegistry("121.0.2277.83"), loader: noLoader, minVersion: "120.0.2210.91", want: StatusInstalledNewer},
+		{name: "found by the loader only", registry: RegistrySnapshot{}, loader: fakeLoader{version: "121.0.2277.83"}, minVersion: "120.0.2210.91", want: StatusInstalledNewer},
+		{name: "loader preview channel", registry: RegistrySnapshot{}, loader: fakeLoader{version: "122.0.2365.3 dev"}, minVersion: "122.0.2365.3", want: StatusInstalledOK},
+		{name: "loader version is preferred", registry: runtimeRegistry("90.0.818.66"), loader: fakeLoader{version: "121.0.2277.83"}, minVersion: "120.0.2210.91", want: StatusInstalledNewer},
+		{name: "invalid minimum", registry: runtimeRegistry("120.0.2210.91"), loader: noLoader, minVersion: "latest", want: StatusNotInstalled, wantErr: true},
+		{name: "registry failure", registry: failingRegistry{}, loader: noLoader, want: StatusNotInstalled, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			detector := &Detector{Registry: test.registry, Loader: test.loader, Architecture: ArchX64}
+			got, err := detector.Status(test.minVersion)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("Status() error = %v, want error %t", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("Status() = %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDetectorStatusRegistryError(t *testing.T) {
+	detector := &Detector{Registry: failingRegistry{}, Loader: noLoader, Architecture: ArchX64}
+	_, err := detector.Status("")
+	if !errors.Is(err, ErrRegistryAccess) {
+		t.Errorf("Status() error = %v, want ErrRegistryAccess", err)
+	}
+}
+
+func TestRuntimeStatusString(t *testing.T) {
+	tests := []struct {
+		status RuntimeStatus
+		want   string
+	}{
+		{StatusNotInstalled, "not-installed"},
+		{StatusInstalledTooOld, "too-old"},
+		{StatusInstalledOK, "ok"},
+		{StatusInstalledNewer, "newer"},
+		{StatusNotApplicable, "not-applicable"},
+		{RuntimeStatus(100), "unknown"},
+	}
+	for _, test := range tests {
+		if got := test.status.String(); got != test.want {
+			t.Errorf("RuntimeStatus(%d).String() = %q, want %q", int(test.status), got, test.want)
+		}
+	}
+}
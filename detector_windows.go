@@ -0,0 +1,15 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+// Status returns the status of the installed runtime, as found by Detect, compared to minVersion.
+// If minVersion is blank, any installed version is StatusInstalledOK.
+// Returns an error if the runtime could not be detected or the versions could not be compared.
+func Status(minVersion string) (RuntimeStatus, error) {
+	info, err := Detect()
+	if err != nil {
+		return StatusNotInstalled, err
+	}
+	return defaultDetector.statusOf(info, minVersion)
+}
@@ -0,0 +1,160 @@
+package webview2runtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DiagnosticsReport describes everything relevant to the runtime on this machine.
+// It is intended to be attached to support tickets, either as JSON or as text.
+type DiagnosticsReport struct {
+	GeneratedAt time.Time `json:"generatedAt"`
+
+	OSVersion           string `json:"osVersion"`
+	Architecture        string `json:"architecture"`
+	ProcessArchitecture string `json:"processArchitecture"`
+	Elevated            bool   `json:"elevated"`
+
+	InstalledVersion string `json:"installedVersion"`
+	Installations    []Info `json:"installations"`
+
+	// Policies are the EdgeUpdate group policy values.
+	Policies map[string]string `json:"policies"`
+
+	TempDir       string `json:"tempDir"`
+	TempFreeBytes uint64 `json:"tempFreeBytes"`
+
+	// ProxyEnvironment are the proxy environment variables that are set.
+	ProxyEnvironment map[string]string `json:"proxyEnvironment"`
+	// ProxySettings are the WinINet proxy settings of the current user.
+	ProxySettings map[string]string `json:"proxySettings"`
+
+	// InstallerProcesses are the processes spawned by the last installer this package ran, if any.
+	InstallerProcesses []SpawnedProcess `json:"installerProcesses,omitempty"`
+
+	// EdgeUpdateLogs are the ends of the installer and EdgeUpdate logs, keyed by path.
+	EdgeUpdateLogs map[string]string `json:"edgeUpdateLogs"`
+
+	// Errors are the problems found while generating the report.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// JSON returns the report as indented JSON.
+func (r *DiagnosticsReport) JSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+// String returns the report as human readable text.
+func (r *DiagnosticsReport) String() string {
+	var builder strings.Builder
+	_ = r.WriteText(&builder)
+	return builder.String()
+}
+
+// WriteText writes the report to out as human readable text.
+func (r *DiagnosticsReport) WriteText(out io.Writer) error {
+	w := &errWriter{w: out}
+	w.printf("WebView2 runtime diagnostics generated %s\n\n", r.GeneratedAt.Format(time.RFC3339))
+	w.printf("Windows %s (%s), process %s, elevated: %t\n", r.OSVersion, r.Architecture, r.ProcessArchitecture, r.Elevated)
+	installed := r.InstalledVersion
+	if installed == "" {
+		installed = "not installed"
+	}
+	w.printf("Installed version: %s\n\n", installed)
+
+	w.printf("Installations:\n")
+	if len(r.Installations) == 0 {
+		w.printf("  none\n")
+	}
+	for _, info := range r.Installations {
+		w.printf("  %s %s (%s, %s) %s\n", info.Channel, info.Version, info.Scope, info.DetectionMethod, info.Location)
+	}
+	w.printf("\nEdgeUpdate policies:\n")
+	w.printMap(r.Policies)
+	w.printf("\nTemp directory: %s (%d MB free)\n", r.TempDir, r.TempFreeBytes/(1024*1024))
+	w.printf("\nProxy environment:\n")
+	w.printMap(r.ProxyEnvironment)
+	w.printf("\nProxy settings:\n")
+	w.printMap(r.ProxySettings)
+
+	if len(r.InstallerProcesses) > 0 {
+		w.printf("\nProcesses spawned by the last installer:\n")
+		for _, process := range r.InstallerProcesses {
+			w.printf("  %d %s\n", process.PID, process.Path)
+		}
+	}
+	for _, path := range sortedKeys(r.EdgeUpdateLogs) {
+		w.printf("\n%s:\n%s\n", path, r.EdgeUpdateLogs[path])
+	}
+	if len(r.Errors) > 0 {
+		w.printf("\nErrors:\n")
+		for _, err := range r.Errors {
+			w.printf("  %s\n", err)
+		}
+	}
+	return w.err
+}
+
+// errWriter writes formatted text, remembering the first error.
+type errWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (w *errWriter) printf(format string, args ...interface{}) {
+	if w.err == nil {
+		_, w.err = fmt.Fprintf(w.w, format, args...)
+	}
+}
+
+func (w *errWriter) printMap(values map[string]string) {
+	if len(values) == 0 {
+		w.printf("  none\n")
+	}
+	for _, key := range sortedKeys(values) {
+		w.printf("  %s = %s\n", key, values[key])
+	}
+}
+
+func sortedKeys(values map[string]string) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// tailFile returns up to the last maxBytes of the file, starting at a line boundary.
+func tailFile(path string, maxBytes int64) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+	offset := info.Size() - maxBytes
+	if offset < 0 {
+		offset = 0
+	}
+	data := make([]byte, info.Size()-offset)
+	_, err = file.ReadAt(data, offset)
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	text := string(data)
+	if offset > 0 {
+		if newline := strings.IndexByte(text, '\n'); newline >= 0 {
+			text = text[newline+1:]
+		}
+	}
+	return text, nil
+}
@@ -0,0 +1,120 @@
+package webview2runtime
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Exit codes returned by RunDiagnostics.
+const (
+	DiagnosticsInstalled    = 0
+	DiagnosticsNotInstalled = 1
+	DiagnosticsTooOld       = 2
+	DiagnosticsError        = 3
+	// DiagnosticsNotApplicable is returned on platforms other than Windows, where the runtime does not exist.
+	DiagnosticsNotApplicable = 4
+)
+
+// DiagnosticsResult is the detection result printed by RunDiagnostics.
+type DiagnosticsResult struct {
+	Status     string `json:"status"`
+	Version    string `json:"version,omitempty"`
+	MinVersion string `json:"minVersion,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// RunDiagnosticsMain runs RunDiagnostics with the command line arguments and exits with its status code.
+// It allows a tiny main package to provide a scriptable runtime check:
+//
+//	func main() {
+//	    webview2runtime.RunDiagnosticsMain()
+//	}
+func RunDiagnosticsMain() {
+	os.Exit(RunDiagnostics(os.Args[1:], os.Stdout))
+}
+
+// RunDiagnostics detects the runtime and writes the result to out.
+// Accepted arguments are:
+//
+//	-min-version <version>  the minimum acceptable version
+//	-format text|json       the output format, defaults to text
+//
+// Returns DiagnosticsInstalled, DiagnosticsNotInstalled, DiagnosticsTooOld, DiagnosticsError or,
+// on platforms other than Windows, DiagnosticsNotApplicable.
+func RunDiagnostics(args []string, out io.Writer) int {
+	flags := flag.NewFlagSet("webview2runtime", flag.ContinueOnError)
+	flags.SetOutput(out)
+	minVersion := flags.String("min-version", "", "the minimum acceptable runtime version")
+	format := flags.String("format", "text", "the output format: text or json")
+	err := flags.Parse(args)
+	if err != nil {
+		return DiagnosticsError
+	}
+	if *format != "text" && *format != "json" {
+		fmt.Fprintf(out, "invalid format: %s\n", *format)
+		return DiagnosticsError
+	}
+
+	result, code := diagnoseSystem(*minVersion)
+	err = writeDiagnostics(out, *format, result)
+	if err != nil {
+		return DiagnosticsError
+	}
+	return code
+}
+
+// diagnose checks the installed runtime against the minimum version and returns the result and exit code.
+func diagnose(detector *Detector, minVersion string) (DiagnosticsResult, int) {
+	result := DiagnosticsResult{MinVersion: minVersion}
+	info, err := detector.Detect()
+	var status RuntimeStatus
+	if err == nil {
+		if info != nil {
+			result.Version = info.Version
+		}
+		status, err = detector.statusOf(info, minVersion)
+	}
+	if err != nil {
+		result.Status = "error"
+		result.Error = err.Error()
+		return result, DiagnosticsError
+	}
+	switch status {
+	case StatusNotInstalled:
+		result.Status = "not-installed"
+		return result, DiagnosticsNotInstalled
+	case StatusInstalledTooOld:
+		result.Status = "too-old"
+		return result, DiagnosticsTooOld
+	}
+	result.Status = "installed"
+	return result, DiagnosticsInstalled
+}
+
+// writeDiagnostics writes the result to out in the given format.
+func writeDiagnostics(out io.Writer, format string, result DiagnosticsResult) error {
+	if format == "json" {
+		return json.NewEncoder(out).Encode(result)
+	}
+	var err error
+	switch result.Status {
+	case "not-installed":
+		_, err = fmt.Fprintln(out, "WebView2 runtime is not installed")
+	case "not-applicable":
+		_, err = fmt.Fprintln(out, "WebView2 runtime is only available on Windows")
+	case "too-old":
+		_, err = fmt.Fprintf(out, "WebView2 runtime %s is installed but %s or newer is required\n", result.Version, result.MinVersion)
+	case "error":
+		if result.Version == "" {
+			_, err = fmt.Fprintf(out, "Unable to check WebView2 runtime: %s\n", result.Error)
+		} else {
+			_, err = fmt.Fprintf(out, "Unable to check WebView2 runtime %s: %s\n", result.Version, result.Error)
+		}
+	default:
+		_, err = fmt.Fprintf(out, "WebView2 runtime %s is installed\n", result.Version)
+	}
+	return err
+}
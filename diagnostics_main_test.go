@@ -0,0 +1,158 @@
+package webview2runtime
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestDiagnose(t *testing.T) {
+	tests := []struct {
+		name       string
+		registry   RegistryReader
+		minVersion string
+		result     DiagnosticsResult
+		code       int
+	}{
+		{
+			name:     "installed",
+			registry: runtimeRegistry("120.0.2210.91"),
+			result:   DiagnosticsResult{Status: "installed", Version: "120.0.2210.91"},
+			code:     DiagnosticsInstalled,
+		},
+		{
+			name:       "newer than required",
+			registry:   runtimeRegistry("120.0.2210.91"),
+			minVersion: "119.0.2151.97",
+			result:     DiagnosticsResult{Status: "installed", Version: "120.0.2210.91", MinVersion: "119.0.2151.97"},
+			code:       DiagnosticsInstalled,
+		},
+		{
+			name:       "too old",
+			registry:   runtimeRegistry("119.0.2151.97"),
+			minVersion: "120.0.2210.91",
+			result:     DiagnosticsResult{Status: "too-old", Version: "119.0.2151.97", MinVersion: "120.0.2210.91"},
+			code:       DiagnosticsTooOld,
+		},
+		{
+			name:       "missing",
+			registry:   RegistrySnapshot{},
+			minVersion: "120.0.2210.91",
+			result:     DiagnosticsResult{Status: "not-installed", MinVersion: "120.0.2210.91"},
+			code:       DiagnosticsNotInstalled,
+		},
+		{
+			name:       "invalid minimum version",
+			registry:   runtimeRegistry("120.0.2210.91"),
+			minVersion: "latest",
+			result:     DiagnosticsResult{Status: "error", Version: "120.0.2210.91", MinVersion: "latest", Error: "loader comparison not available"},
+			code:       DiagnosticsError,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			detector := &Detector{Registry: test.registry, Loader: noLoader, Architecture: ArchX64}
+			result, code := diagnose(detector, test.minVersion)
+			if result != test.result || code != test.code {
+				t.Errorf("diagnose() = %+v, %d, want %+v, %d", result, code, test.result, test.code)
+			}
+		})
+	}
+}
+
+func TestDiagnoseRegistryError(t *testing.T) {
+	detector := &Detector{Registry: failingRegistry{}, Loader: noLoader, Architecture: ArchX64}
+	result, code := diagnose(detector, "")
+	if result.Status != "error" || result.Version != "" || result.Error == "" || code != DiagnosticsError {
+		t.Errorf("diagnose() = %+v, %d, want an error without a version", result, code)
+	}
+}
+
+func TestWriteDiagnostics(t *testing.T) {
+	tests := []struct {
+		name   string
+		result DiagnosticsResult
+		want   string
+	}{
+		{"installed", DiagnosticsResult{Status: "installed", Version: "120.0.2210.91"}, "WebView2 runtime 120.0.2210.91 is installed\n"},
+		{"not installed", DiagnosticsResult{Status: "not-installed"}, "WebView2 runtime is not installed\n"},
+		{
+			"too old",
+			DiagnosticsResult{Status: "too-old", Version: "119.0.2151.97", MinVersion: "120.0.2210.91"},
+			"WebView2 runtime 119.0.2151.97 is installed but 120.0.2210.91 or newer is required\n",
+		},
+		{"not applicable", DiagnosticsResult{Status: "not-applicable"}, "WebView2 runtime is only available on Windows\n"},
+		{
+			"error without a version",
+			DiagnosticsResult{Status: "error", Error: "access denied"},
+			"Unable to check WebView2 runtime: access denied\n",
+		},
+		{
+			"error with a version",
+			DiagnosticsResult{Status: "error", Version: "120.0.2210.91", Error: "invalid version"},
+			"Unable to check WebView2 runtime 120.0.2210.91: invalid version\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := writeDiagnostics(&out, "text", test.result)
+			if err != nil {
+				t.Fatalf("writeDiagnostics() error = %v", err)
+			}
+			if out.String() != test.want {
+				t.Errorf("writeDiagnostics() wrote %q, want %q", out.String(), test.want)
+			}
+
+			out.Reset()
+			err = writeDiagnostics(&out, "json", test.result)
+			if err != nil {
+				t.Fatalf("writeDiagnostics() json error = %v", err)
+			}
+			var decoded DiagnosticsResult
+			err = json.Unmarshal(out.Bytes(), &decoded)
+			if err != nil || decoded != test.result {
+				t.Errorf("writeDiagnostics() json = %s, %v, want %+v", out.String(), err, test.result)
+			}
+		})
+	}
+}
+
+func TestRunDiagnostics(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"unknown flag", []string{"-verbose"}, "flag provided but not defined"},
+		{"invalid format", []string{"-format", "xml"}, "invalid format: xml"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out bytes.Buffer
+			code := RunDiagnostics(test.args, &out)
+			if code != DiagnosticsError || !strings.Contains(out.String(), test.want) {
+				t.Errorf("RunDiagnostics(%q) = %d, %q, want %d and %q", test.args, code, out.String(), DiagnosticsError, test.want)
+			}
+		})
+	}
+}
+
+func TestRunDiagnosticsNotApplicable(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the runtime is applicable on Windows")
+	}
+	var out bytes.Buffer
+	code := RunDiagnostics([]string{"-min-version", "120.0.2210.91", "-format", "json"}, &out)
+	var result DiagnosticsResult
+	err := json.Unmarshal(out.Bytes(), &result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := DiagnosticsResult{Status: "not-applicable", MinVersion: "120.0.2210.91"}
+	if code != DiagnosticsNotApplicable || result != want {
+		t.Errorf("RunDiagnostics() = %d, %+v, want %d, %+v", code, result, DiagnosticsNotApplicable, want)
+	}
+}
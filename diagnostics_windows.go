@@ -0,0 +1,107 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"fmt"
+	"golang.org/x/sys/windows"
+	"os"
+	"strings"
+	"time"
+)
+
+// internetSettingsKey holds the WinINet proxy settings of the current user.
+const internetSettingsKey = `HKCU\Software\Microsoft\Windows\CurrentVersion\Internet Settings`
+
+// proxyEnvironment are the environment variables that configure HTTP proxies.
+var proxyEnvironment = []string{"HTTP_PROXY", "HTTPS_PROXY", "NO_PROXY"}
+
+// GenerateDiagnostics gathers a DiagnosticsReport. Sections that cannot be gathered are
+// recorded in the Errors of the report rather than stopping the report being generated.
+// The report is always returned. The error is the first problem found, if any.
+func GenerateDiagnostics() (*DiagnosticsReport, error) {
+	version := windows.RtlGetVersion()
+	report := &DiagnosticsReport{
+		GeneratedAt:         time.Now(),
+		OSVersion:           fmt.Sprintf("%d.%d.%d", version.MajorVersion, version.MinorVersion, version.BuildNumber),
+		Architecture:        nativeArchitecture().String(),
+		ProcessArchitecture: processArchitecture().String(),
+		Elevated:            IsElevated(),
+		InstalledVersion:    GetInstalledVersion(),
+		TempDir:             os.TempDir(),
+		ProxyEnvironment:    map[string]string{},
+		InstallerProcesses:  lastSpawnedProcesses(),
+		EdgeUpdateLogs:      map[string]string{},
+	}
+	var firstErr error
+	record := func(err error) {
+		if err == nil {
+			return
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+		report.Errors = append(report.Errors, err.Error())
+	}
+
+	var err error
+	report.Installations, err = ListInstallations()
+	record(err)
+	report.Policies, err = defaultDetector.readValues(edgeUpdatePolicyKey)
+	record(err)
+	report.ProxySettings, err = defaultDetector.readValues(internetSettingsKey)
+	record(err)
+	report.ProxySettings = filterProxySettings(report.ProxySettings)
+	report.TempFreeBytes, err = freeDiskSpace(report.TempDir)
+	record(err)
+
+	for _, name := range proxyEnvironment {
+		for _, variable := range []string{name, strings.ToLower(name)} {
+			if value := os.Getenv(variable); value != "" {
+				report.ProxyEnvironment[variable] = value
+			}
+		}
+	}
+	for _, path := range InstallerLogPaths() {
+		tail, err := tailFile(path, installerLogTail)
+		if os.IsNotExist(err) {
+			continue
+		}
+		record(err)
+		if err == nil {
+			report.EdgeUpdateLogs[path] = tail
+		}
+	}
+	return report, firstErr
+}
+
+// diagnoseSystem checks the runtime installed on this machine.
+func diagnoseSystem(minVersion string) (DiagnosticsResult, int) {
+	return diagnose(defaultDetector, minVersion)
+}
+
+// filterProxySettings returns only the proxy related WinINet settings.
+func filterProxySettings(settings map[string]string) map[string]string {
+	result := map[string]string{}
+	for _, name := range []string{"ProxyEnable", "ProxyServer", "ProxyOverride", "AutoConfigURL", "AutoDetect"} {
+		if value, ok := settings[name]; ok {
+			result[name] = value
+		}
+	}
+	return result
+}
+
+// freeDiskSpace returns the number of bytes available to the current user on the disk holding dir.
+func freeDiskSpace(dir string) (uint64, error) {
+	dirPtr, err := windows.UTF16PtrFromString(dir)
+	if err != nil {
+		return 0, err
+	}
+	var free, total, totalFree uint64
+	err = windows.GetDiskFreeSpaceEx(dirPtr, &free, &total, &totalFree)
+	if err != nil {
+		return 0, fmt.Errorf("unable to get free space of %s: %w", dir, err)
+	}
+	return free, nil
+}
@@ -0,0 +1,89 @@
+package webview2runtime
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// connectivityTimeout is how long to wait when checking the download server can be reached.
+const connectivityTimeout = 5 * time.Second
+
+// Distribution is a way of distributing the runtime with an app.
+type Distribution int
+
+const (
+	// DistributionEvergreen relies on the evergreen runtime, installed once and kept up to date by EdgeUpdate.
+	DistributionEvergreen Distribution = iota
+	// DistributionFixedVersion bundles a fixed version runtime with the app.
+	DistributionFixedVersion
+)
+
+// String returns the name of the distribution.
+func (d Distribution) String() string {
+	if d == DistributionFixedVersion {
+		return "fixed-version"
+	}
+	return "evergreen"
+}
+
+// Recommendation is the distribution recommended for this machine and the reasons for it.
+type Recommendation struct {
+	Distribution Distribution
+	Reasons      []string
+}
+
+// recommendDistribution recommends a distribution given the support for the version of Windows
+// and a function that checks the download server can be reached.
+func (d *Detector) recommendDistribution(supported bool, support OSSupport, connect func() error) (*Recommendation, error) {
+	var reasons []string
+
+	if !supported || support.LastSupportedRuntime != "" {
+		reasons = append(reasons, fmt.Sprintf("Windows %s no longer receives evergreen runtime updates", support.OSVersion))
+	}
+
+	policy, err := d.installPolicy()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read install policy: %w", err)
+	}
+	if policy == InstallPolicyDisabled {
+		reasons = append(reasons, "group policy prevents the runtime being installed")
+	}
+	updatesDisabled, err := d.updatesDisabledByPolicy()
+	if err != nil {
+		return nil, fmt.Errorf("unable to read update policy: %w", err)
+	}
+	if updatesDisabled {
+		reasons = append(reasons, "group policy prevents the runtime being updated automatically")
+	}
+
+	err = connect()
+	if err != nil {
+		reasons = append(reasons, fmt.Sprintf("the download server cannot be reached: %s", err))
+	}
+
+	if len(reasons) > 0 {
+		return &Recommendation{Distribution: DistributionFixedVersion, Reasons: reasons}, nil
+	}
+	return &Recommendation{
+		Distribution: DistributionEvergreen,
+		Reasons:      []string{"the evergreen runtime can be installed and kept up to date on this machine"},
+	}, nil
+}
+
+// checkConnectivity checks the given URL can be reached using the given client.
+func checkConnectivity(ctx context.Context, client *http.Client, url string) error {
+	ctx, cancel := context.WithTimeout(ctx, connectivityTimeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := client.Do(request)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
@@ -0,0 +1,95 @@
+package webview2runtime
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestDetectorRecommendDistribution(t *testing.T) {
+	windows10 := OSSupport{OSVersion: "10.0.19045"}
+	windows7 := OSSupport{OSVersion: "6.1.7601", LastSupportedRuntime: "109"}
+	reachable := func() error { return nil }
+	unreachable := func() error { return errors.New("no route to host") }
+	policies := func(values map[string]string) RegistrySnapshot {
+		return RegistrySnapshot{edgeUpdatePolicyKey: values}
+	}
+
+	tests := []struct {
+		name         string
+		supported    bool
+		support      OSSupport
+		registry     RegistryReader
+		connect      func() error
+		distribution Distribution
+		reasons      int
+	}{
+		{"evergreen", true, windows10, RegistrySnapshot{}, reachable, DistributionEvergreen, 1},
+		{"unsupported Windows", false, OSSupport{OSVersion: "6.0.6002"}, RegistrySnapshot{}, reachable, DistributionFixedVersion, 1},
+		{"Windows without updates", true, windows7, RegistrySnapshot{}, reachable, DistributionFixedVersion, 1},
+		{"install disabled by default", true, windows10, policies(map[string]string{"InstallDefault": "0"}), reachable, DistributionFixedVersion, 1},
+		{"install disabled for the runtime", true, windows10, policies(map[string]string{"InstallDefault": "1", "Install" + clientGUID: "0"}), reachable, DistributionFixedVersion, 1},
+		{"install allowed for the runtime", true, windows10, policies(map[string]string{"InstallDefault": "0", "Install" + clientGUID: "1"}), reachable, DistributionEvergreen, 1},
+		{"install per-user only", true, windows10, policies(map[string]string{"InstallDefault": "3"}), reachable, DistributionEvergreen, 1},
+		{"updates disabled", true, windows10, policies(map[string]string{"UpdateDefault": "0"}), reachable, DistributionFixedVersion, 1},
+		{"manual updates only", true, windows10, policies(map[string]string{"Update" + clientGUID: "2"}), reachable, DistributionFixedVersion, 1},
+		{"automatic updates only", true, windows10, policies(map[string]string{"UpdateDefault": "3"}), reachable, DistributionEvergreen, 1},
+		{"download server unreachable", true, windows10, RegistrySnapshot{}, unreachable, DistributionFixedVersion, 1},
+		{
+			"every reason", true, windows7,
+			policies(map[string]string{"InstallDefault": "0", "UpdateDefault": "0"}),
+			unreachable, DistributionFixedVersion, 4,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			detector := &Detector{Registry: test.registry, Loader: noLoader, Architecture: ArchX64}
+			recommendation, err := detector.recommendDistribution(test.supported, test.support, test.connect)
+			if err != nil {
+				t.Fatalf("recommendDistribution() error = %v", err)
+			}
+			if recommendation.Distribution != test.distribution {
+				t.Errorf("Distribution = %s, want %s", recommendation.Distribution, test.distribution)
+			}
+			if len(recommendation.Reasons) != test.reasons {
+				t.Errorf("Reasons = %q, want %d", recommendation.Reasons, test.reasons)
+			}
+		})
+	}
+}
+
+func TestDetectorRecommendDistributionRegistryError(t *testing.T) {
+	detector := &Detector{Registry: failingRegistry{}, Loader: noLoader, Architecture: ArchX64}
+	connected := false
+	_, err := detector.recommendDistribution(true, OSSupport{}, func() error {
+		connected = true
+		return nil
+	})
+	if !errors.Is(err, ErrRegistryAccess) {
+		t.Errorf("recommendDistribution() error = %v, want ErrRegistryAccess", err)
+	}
+	if connected {
+		t.Error("recommendDistribution() checked connectivity after the registry could not be read")
+	}
+}
+
+func TestCheckConnectivity(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodHead {
+			t.Errorf("method = %s, want HEAD", r.Method)
+		}
+	}))
+	url := server.URL
+	err := checkConnectivity(context.Background(), server.Client(), url)
+	if err != nil {
+		t.Errorf("checkConnectivity() error = %v", err)
+	}
+
+	server.Close()
+	err = checkConnectivity(context.Background(), http.DefaultClient, url)
+	if err == nil {
+		t.Error("checkConnectivity() error = nil, want an error once the server is closed")
+	}
+}
@@ -0,0 +1,30 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+)
+
+// RecommendDistribution evaluates this machine and recommends whether the evergreen runtime is viable
+// or a fixed version runtime should be bundled instead.
+//
+// A fixed version runtime is recommended if any of the following are true:
+//
+//   - Windows is older than Windows 10, as evergreen updates are no longer provided for it
+//   - Group policy prevents the runtime being installed or updated
+//   - The bootstrapper download server cannot be reached
+//
+// Otherwise evergreen is recommended.
+func RecommendDistribution() (*Recommendation, error) {
+	return defaultDetector.RecommendDistribution()
+}
+
+// RecommendDistribution is the same as the package level RecommendDistribution but uses this Detector.
+func (d *Detector) RecommendDistribution() (*Recommendation, error) {
+	supported, support := IsOSSupported()
+	return d.recommendDistribution(supported, support, func() error {
+		return checkConnectivity(context.Background(), getHTTPClient(), bootstrapperURL)
+	})
+}
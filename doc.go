@@ -0,0 +1,8 @@
+// Package webview2runtime detects, installs and manages the Microsoft Edge WebView2 runtime.
+//
+// All functions and methods are safe for concurrent use unless documented otherwise. Installs,
+// uninstalls and repairs are serialised: within the process, and with other processes through a
+// named mutex. The package level settings, eg SetLogger, SetHTTPClient and SetLoaderPath, may be
+// changed at any time and apply to calls made after they return. A Detector is safe for concurrent
+// use as long as its fields are not changed and its RegistryReader and Loader are themselves safe.
+package webview2runtime
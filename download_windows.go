@@ -0,0 +1,210 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	httpClientLock sync.Mutex
+	httpClient     = http.DefaultClient
+)
+
+// SetHTTPClient sets the HTTP client used for all requests made by this package.
+// Passing nil restores http.DefaultClient, which honours the system proxy environment variables.
+func SetHTTPClient(client *http.Client) {
+	httpClientLock.Lock()
+	defer httpClientLock.Unlock()
+	if client == nil {
+		client = http.DefaultClient
+	}
+	httpClient = client
+}
+
+func getHTTPClient() *http.Client {
+	httpClientLock.Lock()
+	defer httpClientLock.Unlock()
+	return httpClient
+}
+
+// bootstrapperURL is the Microsoft download link for the evergreen bootstrapper.
+const bootstrapperURL = `https://go.microsoft.com/fwlink/p/?LinkId=2124703`
+
+// DownloadBootstrapper downloads the bootstrapper to the given directory without running it.
+// Returns the path of the downloaded file.
+func DownloadBootstrapper(ctx context.Context, destDir string) (string, error) {
+	return DownloadBootstrapperWithOptions(ctx, destDir, nil)
+}
+
+// DownloadBootstrapperWithOptions is the same as DownloadBootstrapper but downloads using the given options.
+// The file is checked against the SHA256, RequireSignature and MinimumInstallerVersion options,
+// and removed if a check fails. The options may be nil.
+func DownloadBootstrapperWithOptions(ctx context.Context, destDir string, options *InstallOptions) (string, error) {
+	err := options.validate()
+	if err != nil {
+		return "", err
+	}
+	installer, unlock, err := options.installerPath(destDir)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+	err = downloadBootstrapperTo(ctx, installer, options)
+	if err != nil {
+		_ = os.Remove(installer)
+		return "", err
+	}
+	err = options.checkInstaller(installer)
+	if err != nil {
+		_ = os.Remove(installer)
+		return "", err
+	}
+	return installer, nil
+}
+
+// downloadBootstrapperTo downloads the bootstrapper to the given path, or copies it from the cache.
+// Each of the bootstrapper URLs is tried in turn until one succeeds.
+// Returns the error from the last URL if they all fail.
+func downloadBootstrapperTo(ctx context.Context, installer string, options *InstallOptions) error {
+	cache := options.cachePolicy()
+	if cache != nil && cache.copyTo(installer, options) {
+		return nil
+	}
+	downloader, err := options.downloader()
+	if err != nil {
+		return err
+	}
+
+	options.reportPhase(PhaseDownloading)
+	for _, url := range options.bootstrapperURLs() {
+		start := time.Now()
+		err = downloader.Download(ctx, url, installer)
+		event := DownloadTelemetry{URL: url, Duration: time.Since(start), Err: err}
+		if info, statErr := os.Stat(installer); err == nil && statErr == nil {
+			event.Bytes = info.Size()
+		}
+		getTelemetry().Download(event)
+		if err == nil || ctx.Err() != nil {
+			break
+		}
+	}
+	if err == nil && cache != nil {
+		cache.store(installer, options)
+	}
+	return err
+}
+
+// downloadWithRetry downloads the url to the given path, retrying failed attempts according to the
+// Retry option. Retries resume from the end of the partially downloaded file where the server allows it.
+func downloadWithRetry(ctx context.Context, client *http.Client, url string, path string, options *InstallOptions) error {
+	policy := options.retryPolicy()
+	err := downloadWithTimeout(ctx, client, url, path, false, options)
+	for attempt := 1; attempt < policy.attempts() && isRetryable(err); attempt++ {
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(policy.backoff(attempt)):
+		}
+		logEvent("retrying download", "url", url, "attempt", attempt+1, "error", err)
+		err = downloadWithTimeout(ctx, client, url, path, true, options)
+	}
+	return err
+}
+
+// isRetryable returns true if the download error may succeed if tried again:
+// network failures, server errors and rate limiting.
+func isRetryable(err error) bool {
+	var downloadErr *DownloadError
+	if !errors.As(err, &downloadErr) {
+		return false
+	}
+	status := downloadErr.StatusCode
+	return status == 0 || status >= 500 || status == http.StatusTooManyRequests || status == http.StatusRequestedRangeNotSatisfiable
+}
+
+// downloadWithTimeout downloads the url to the given path, giving up after the DownloadTimeout option.
+func downloadWithTimeout(ctx context.Context, client *http.Client, url string, path string, resume bool, options *InstallOptions) error {
+	timeout := options.downloadTimeout()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return download(ctx, client, url, path, resume, options)
+}
+
+// download downloads the url to the given path, reporting progress to the DownloadProgress option.
+// If resume is true and the file already exists, only the rest of the file is requested.
+// The file is downloaded from the start if the server does not support range requests.
+func download(ctx context.Context, client *http.Client, url string, path string, resume bool, options *InstallOptions) error {
+	var offset int64
+	if resume {
+		info, err := os.Stat(path)
+		if err == nil {
+			offset = info.Size()
+		}
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	if offset > 0 {
+		request.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+	logEvent("downloading", "url", url, "path", path, "offset", offset)
+	resp, err := client.Do(request)
+	if err != nil {
+		logEvent("download failed", "url", url, "error", err)
+		return &DownloadError{URL: url, Err: err}
+	}
+	defer resp.Body.Close()
+	logEvent("download response", "url", url, "status", resp.StatusCode, "length", resp.ContentLength)
+
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		flags = os.O_WRONLY | os.O_APPEND
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable:
+		// The partial file is no good, so start again on the next attempt
+		_ = os.Remove(path)
+		return &DownloadError{URL: url, StatusCode: resp.StatusCode}
+	case resp.StatusCode != http.StatusOK:
+		return &DownloadError{URL: url, StatusCode: resp.StatusCode}
+	default:
+		offset = 0
+	}
+	out, err := os.OpenFile(path, flags, 0755)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	var body io.Reader = resp.Body
+	if options != nil && options.DownloadProgress != nil {
+		total := resp.ContentLength
+		if total >= 0 {
+			total += offset
+		}
+		body = io.TeeReader(body, &progressWriter{
+			downloaded: offset,
+			total:      total,
+			callback:   options.DownloadProgress,
+		})
+	}
+	written, err := io.Copy(out, body)
+	if err != nil {
+		logEvent("download failed", "url", url, "error", err)
+		return &DownloadError{URL: url, Err: err}
+	}
+	logEvent("downloaded", "url", url, "path", path, "bytes", offset+written)
+	return nil
+}
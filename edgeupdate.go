@@ -0,0 +1,52 @@
+package webview2runtime
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// edgeUpdateKeys are the keys EdgeUpdate records its own location under, by scope.
+var edgeUpdateKeys = []struct {
+	key   string
+	scope Scope
+}{
+	{`HKLM\SOFTWARE\WOW6432Node\Microsoft\EdgeUpdate`, ScopeMachine},
+	{`HKLM\SOFTWARE\Microsoft\EdgeUpdate`, ScopeMachine},
+	{`HKCU\Software\Microsoft\EdgeUpdate`, ScopeUser},
+}
+
+// ErrEdgeUpdateNotFound is returned when MicrosoftEdgeUpdate.exe cannot be found.
+var ErrEdgeUpdateNotFound = errors.New("MicrosoftEdgeUpdate.exe not found")
+
+// EdgeUpdatePath is the same as the package level EdgeUpdatePath but uses this Detector.
+func (d *Detector) EdgeUpdatePath(scope Scope) (string, error) {
+	for _, key := range edgeUpdateKeys {
+		if scope != ScopeNone && key.scope != scope {
+			continue
+		}
+		values, err := d.readValues(key.key)
+		if err != nil {
+			return "", err
+		}
+		path := values["path"]
+		if path == "" {
+			continue
+		}
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	if scope != ScopeUser {
+		programFiles := os.Getenv("ProgramFiles(x86)")
+		if programFiles == "" {
+			programFiles = os.Getenv("ProgramFiles")
+		}
+		path := filepath.Join(programFiles, "Microsoft", "EdgeUpdate", "MicrosoftEdgeUpdate.exe")
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("%w for scope %s", ErrEdgeUpdateNotFound, scope)
+}
@@ -0,0 +1,94 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// EdgeUpdatePath returns the path of MicrosoftEdgeUpdate.exe for the given scope, or for either scope
+// if ScopeNone is given. Returns an error wrapping ErrEdgeUpdateNotFound if it is not installed.
+func EdgeUpdatePath(scope Scope) (string, error) {
+	return defaultDetector.EdgeUpdatePath(scope)
+}
+
+// UpdateUsingEdgeUpdate asks the installed EdgeUpdate to update the runtime now, rather than downloading
+// the bootstrapper. The runtime is updated in the scope it is installed in, or installed per-machine
+// where possible if it is not installed. Progress is reported to the PhaseChanged option, and the
+// result is verified against ExpectedVersion and passed to OnComplete as for an install.
+// The installer related options, eg Silent, SHA256 and BootstrapperURLs, are not used. The options may be nil.
+// The result is never nil.
+func UpdateUsingEdgeUpdate(ctx context.Context, options *InstallOptions) (*InstallResult, error) {
+	start := time.Now()
+	scope := ScopeNone
+	info, err := GetInstallation()
+	if err != nil {
+		return &InstallResult{Error: err}, err
+	}
+	if info != nil {
+		scope = info.Scope
+	}
+	program, err := EdgeUpdatePath(scope)
+	if err != nil {
+		return &InstallResult{Error: err}, err
+	}
+
+	needsAdmin := "prefers"
+	switch scope {
+	case ScopeMachine:
+		needsAdmin = "true"
+	case ScopeUser:
+		needsAdmin = "false"
+	}
+	args := []string{"/silent", "/install", fmt.Sprintf("appguid=%s&needsadmin=%s", clientGUID, needsAdmin)}
+
+	updateOptions := options.withSilent()
+	updateOptions.HideWindow = true
+	if scope == ScopeUser {
+		// Elevating could update the runtime of a different user
+		updateOptions.Elevation = ElevationNever
+	}
+
+	result := &InstallResult{Installer: program}
+	if options.dryRun() {
+		result.Plan = &Plan{}
+		result.Plan.addDetect()
+		result.Plan.addRun(program, args, updateOptions)
+		return result, nil
+	}
+	release, _, err := acquireInstallLock(ctx, options)
+	if err != nil {
+		result.Error = err
+		return result, err
+	}
+	defer release()
+	options.reportPhase(PhaseInstalling)
+	logEvent("requesting update", "path", program, "scope", scope)
+	result.ExitCode, err = launch(ctx, program, args, updateOptions)
+	if err == nil && result.ExitCode != exitCodeSuccess {
+		err = &InstallerExitError{ExitCode: result.ExitCode}
+	}
+	result.setReason(err)
+	if err == nil {
+		options.reportPhase(PhaseVerifying)
+		err = options.verify(ctx)
+	}
+	result.Success = err == nil
+	result.Error = err
+	logEvent("update finished", "path", program, "version", GetInstalledVersion(), "success", result.Success)
+
+	options.reportPhase(PhaseComplete)
+	reportInstall(result, start)
+	onComplete := options.onComplete()
+	if onComplete != nil {
+		hookErr := onComplete(result)
+		if hookErr != nil {
+			result.Success = false
+			return result, hookErr
+		}
+	}
+	return result, err
+}
@@ -0,0 +1,124 @@
+package webview2runtime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// browserExecutableFolderPolicyKeys hold the BrowserExecutableFolder policy, keyed by executable name.
+var browserExecutableFolderPolicyKeys = []string{
+	`HKLM\SOFTWARE\Policies\Microsoft\Edge\WebView2\BrowserExecutableFolder`,
+	`HKCU\Software\Policies\Microsoft\Edge\WebView2\BrowserExecutableFolder`,
+}
+
+// EffectiveVersion is the same as the package level EffectiveVersion but uses this Detector.
+func (d *Detector) EffectiveVersion() (string, Scope, error) {
+	info, err := d.DetectEffective()
+	if err != nil || info == nil {
+		return "", ScopeNone, err
+	}
+	return info.Version, info.Scope, nil
+}
+
+// DetectEffective is the same as the package level DetectEffective but uses this Detector.
+func (d *Detector) DetectEffective() (*Info, error) {
+	folder := os.Getenv(envBrowserExecutableFolder)
+	if folder != "" {
+		return folderInfo(folder, ScopeEnvironment)
+	}
+
+	folder, err := d.browserExecutableFolderPolicy()
+	if err != nil {
+		return nil, err
+	}
+	if folder != "" {
+		return folderInfo(folder, ScopeFixedVersion)
+	}
+
+	channels, err := d.Channels()
+	if err != nil {
+		return nil, err
+	}
+	if os.Getenv(envReleaseChannelPreference) == "1" {
+		for i, j := 0, len(channels)-1; i < j; i, j = i+1, j-1 {
+			channels[i], channels[j] = channels[j], channels[i]
+		}
+	}
+	allowed := releaseChannels(os.Getenv(envReleaseChannels))
+	for _, info := range channels {
+		if allowed == nil || allowed[info.Channel] {
+			result := info
+			return &result, nil
+		}
+	}
+	return nil, nil
+}
+
+// releaseChannels parses a WEBVIEW2_RELEASE_CHANNELS value, eg `0,2`. Invalid entries are ignored,
+// as they are by the loader. Returns nil, meaning every channel, if no valid channels are listed.
+func releaseChannels(value string) map[Channel]bool {
+	var result map[Channel]bool
+	for _, field := range strings.Split(value, ",") {
+		number, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || number < int(ChannelStable) || number > int(ChannelCanary) {
+			continue
+		}
+		if result == nil {
+			result = map[Channel]bool{}
+		}
+		result[Channel(number)] = true
+	}
+	return result
+}
+
+// folderInfo returns the runtime in the given browser executable folder.
+func folderInfo(folder string, scope Scope) (*Info, error) {
+	version, err := folderVersion(folder)
+	if err != nil {
+		return nil, err
+	}
+	return &Info{
+		Location:        folder,
+		Version:         version,
+		Scope:           scope,
+		DetectionMethod: DetectionFolder,
+	}, nil
+}
+
+// browserExecutableFolderPolicy returns the BrowserExecutableFolder policy set for this executable.
+// The policy value may be named after the executable or be `*` to apply to all executables.
+func (d *Detector) browserExecutableFolderPolicy() (string, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	exeName := filepath.Base(exe)
+	for _, key := range browserExecutableFolderPolicyKeys {
+		values, err := d.readValues(key)
+		if err != nil {
+			return "", err
+		}
+		for _, name := range []string{exeName, "*"} {
+			if folder := values[name]; folder != "" {
+				return folder, nil
+			}
+		}
+	}
+	return "", nil
+}
+
+// folderVersion returns the version of the runtime in the given browser executable folder.
+func folderVersion(folder string) (string, error) {
+	version, err := getFileVersion(filepath.Join(folder, runtimeExecutable))
+	if err == nil {
+		return version, nil
+	}
+	version = versionFromFolder(folder)
+	if version != "" {
+		return version, nil
+	}
+	return "", fmt.Errorf("unable to determine runtime version in %s: %w", folder, err)
+}
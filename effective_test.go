@@ -0,0 +1,149 @@
+package webview2runtime
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// setenv sets an environment variable for the duration of the test.
+func setenv(t *testing.T, key string, value string) {
+	previous, ok := os.LookupEnv(key)
+	err := os.Setenv(key, value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if ok {
+			os.Setenv(key, previous)
+		} else {
+			os.Unsetenv(key)
+		}
+	})
+}
+
+const betaGUID = `{2CD8A007-E189-409D-A2C8-9AF4EF3C72AA}`
+
+func TestDetectorEffectiveVersion(t *testing.T) {
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	envFolder := filepath.Join(t.TempDir(), "119.0.2151.97")
+	policyFolder := filepath.Join(t.TempDir(), "118.0.2088.76")
+	stable := runtimeRegistry("120.0.2210.91")
+	policy := func(key string, name string) RegistrySnapshot {
+		registry := runtimeRegistry("120.0.2210.91")
+		registry[key] = map[string]string{name: policyFolder}
+		return registry
+	}
+	withBeta := runtimeRegistry("120.0.2210.91")
+	withBeta[currentUserClientsKey+betaGUID] = map[string]string{"pv": "121.0.2277.4"}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		registry RegistryReader
+		version  string
+		scope    Scope
+		wantErr  bool
+	}{
+		{name: "not installed", registry: RegistrySnapshot{}, scope: ScopeNone},
+		{name: "machine runtime", registry: stable, version: "120.0.2210.91", scope: ScopeMachine},
+		{
+			name:     "user runtime",
+			registry: RegistrySnapshot{currentUserClientsKey + clientGUID: {"pv": "120.0.2210.91"}},
+			version:  "120.0.2210.91",
+			scope:    ScopeUser,
+		},
+		{
+			name:     "environment folder",
+			env:      map[string]string{envBrowserExecutableFolder: envFolder},
+			registry: policy(browserExecutableFolderPolicyKeys[0], "*"),
+			version:  "119.0.2151.97",
+			scope:    ScopeEnvironment,
+		},
+		{
+			name:     "environment folder without a runtime",
+			env:      map[string]string{envBrowserExecutableFolder: t.TempDir()},
+			registry: stable,
+			wantErr:  true,
+		},
+		{name: "machine policy for all executables", registry: policy(browserExecutableFolderPolicyKeys[0], "*"), version: "118.0.2088.76", scope: ScopeFixedVersion},
+		{name: "user policy for this executable", registry: policy(browserExecutableFolderPolicyKeys[1], filepath.Base(exe)), version: "118.0.2088.76", scope: ScopeFixedVersion},
+		{name: "policy for another executable", registry: policy(browserExecutableFolderPolicyKeys[0], "other.exe"), version: "120.0.2210.91", scope: ScopeMachine},
+		{name: "stable preferred over beta", registry: withBeta, version: "120.0.2210.91", scope: ScopeMachine},
+		{
+			name:     "channel preference reversed",
+			env:      map[string]string{envReleaseChannelPreference: "1"},
+			registry: withBeta,
+			version:  "121.0.2277.4",
+			scope:    ScopeUser,
+		},
+		{
+			name:     "release channels limited to beta",
+			env:      map[string]string{envReleaseChannels: "1"},
+			registry: withBeta,
+			version:  "121.0.2277.4",
+			scope:    ScopeUser,
+		},
+		{
+			name:     "release channel not installed",
+			env:      map[string]string{envReleaseChannels: "3"},
+			registry: withBeta,
+			scope:    ScopeNone,
+		},
+		{
+			name:     "invalid release channels",
+			env:      map[string]string{envReleaseChannels: "beta"},
+			registry: withBeta,
+			version:  "120.0.2210.91",
+			scope:    ScopeMachine,
+		},
+		{name: "registry failure", registry: failingRegistry{}, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Preview channels must only be found from the registry
+			for _, key := range []string{"ProgramFiles(x86)", "ProgramFiles", "LOCALAPPDATA",
+				envBrowserExecutableFolder, envReleaseChannels, envReleaseChannelPreference} {
+				setenv(t, key, test.env[key])
+			}
+			detector := &Detector{Registry: test.registry, Loader: noLoader, Architecture: ArchX64}
+
+			version, scope, err := detector.EffectiveVersion()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("EffectiveVersion() error = %v, want error %t", err, test.wantErr)
+			}
+			if version != test.version || scope != test.scope {
+				t.Errorf("EffectiveVersion() = %q, %s, want %q, %s", version, scope, test.version, test.scope)
+			}
+		})
+	}
+}
+
+func TestReleaseChannels(t *testing.T) {
+	tests := []struct {
+		value string
+		want  []Channel
+	}{
+		{"", nil},
+		{"0", []Channel{ChannelStable}},
+		{"0,2", []Channel{ChannelStable, ChannelDev}},
+		{" 1 , 3 ", []Channel{ChannelBeta, ChannelCanary}},
+		{"1,beta,7,-1", []Channel{ChannelBeta}},
+		{"beta", nil},
+	}
+	for _, test := range tests {
+		got := releaseChannels(test.value)
+		if len(got) != len(test.want) {
+			t.Errorf("releaseChannels(%q) = %v, want %v", test.value, got, test.want)
+			continue
+		}
+		for _, channel := range test.want {
+			if !got[channel] {
+				t.Errorf("releaseChannels(%q) = %v, want %v", test.value, got, test.want)
+			}
+		}
+	}
+}
@@ -0,0 +1,29 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+// EffectiveVersion returns the version of the runtime that CreateCoreWebView2Environment would use
+// for this process when no browserExecutableFolder is given, along with where it comes from.
+// See DetectEffective for the precedence applied.
+// Returns ScopeNone and a blank version if no runtime would be found.
+func EffectiveVersion() (string, Scope, error) {
+	return defaultDetector.EffectiveVersion()
+}
+
+// DetectEffective returns the runtime that CreateCoreWebView2Environment would use for this process
+// when no browserExecutableFolder is given. Unlike Detect, it honours the WEBVIEW2_* environment
+// variables developers use to redirect apps to a specific build.
+//
+// The precedence applied is:
+//
+//  1. The WEBVIEW2_BROWSER_EXECUTABLE_FOLDER environment variable (ScopeEnvironment)
+//  2. The BrowserExecutableFolder policy for this executable, machine before user (ScopeFixedVersion)
+//  3. The stable runtime then the preview channels of Edge, limited to the channels listed in
+//     WEBVIEW2_RELEASE_CHANNELS and searched least stable first if WEBVIEW2_RELEASE_CHANNEL_PREFERENCE is 1
+//
+// Returns nil if no runtime would be found.
+// Returns an error if the registry could not be read or the version of a selected folder could not be found.
+func DetectEffective() (*Info, error) {
+	return defaultDetector.DetectEffective()
+}
@@ -0,0 +1,13 @@
+package webview2runtime
+
+// ElevationMode determines whether the installer may be elevated.
+type ElevationMode int
+
+const (
+	// ElevationAuto elevates the installer using the "runas" verb when the current process is not
+	// elevated, prompting the user with UAC. This is the default.
+	ElevationAuto ElevationMode = iota
+	// ElevationNever runs the installer with the privileges of the current process.
+	// If the installer requires elevation, ErrElevationRequired is returned.
+	ElevationNever
+)
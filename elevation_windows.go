@@ -0,0 +1,21 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"golang.org/x/sys/windows"
+	"unsafe"
+)
+
+// IsElevated returns true if the current process is running with elevated privileges.
+func IsElevated() bool {
+	token := windows.GetCurrentProcessToken()
+	var elevation uint32
+	var returned uint32
+	err := windows.GetTokenInformation(token, windows.TokenElevation, (*byte)(unsafe.Pointer(&elevation)), uint32(unsafe.Sizeof(elevation)), &returned)
+	if err != nil {
+		return false
+	}
+	return elevation != 0
+}
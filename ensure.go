@@ -0,0 +1,120 @@
+package webview2runtime
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrUserDeclined is returned by EnsureInstalled when the user chooses not to install the runtime.
+var ErrUserDeclined = errors.New("the user declined to install the webview2 runtime")
+
+// Stage is a step of EnsureInstalled.
+type Stage int
+
+const (
+	StageDetect Stage = iota
+	StagePrompt
+	StageInstall
+	StageVerify
+)
+
+// String returns the name of the stage.
+func (s Stage) String() string {
+	switch s {
+	case StageDetect:
+		return "detect"
+	case StagePrompt:
+		return "prompt"
+	case StageInstall:
+		return "install"
+	case StageVerify:
+		return "verify"
+	}
+	return "unknown"
+}
+
+// EnsureError is returned by EnsureInstalled and records the stage that failed.
+type EnsureError struct {
+	Stage Stage
+	Err   error
+}
+
+func (e *EnsureError) Error() string {
+	return fmt.Sprintf("webview2 runtime %s failed: %s", e.Stage, e.Err)
+}
+
+func (e *EnsureError) Unwrap() error {
+	return e.Err
+}
+
+// Option configures EnsureInstalled.
+type Option func(*ensureConfig)
+
+type ensureConfig struct {
+	prompt         bool
+	verify         bool
+	useEmbedded    bool
+	owner          uintptr
+	messages       *Messages
+	progress       bool
+	installOptions InstallOptions
+}
+
+// WithoutPrompt installs the runtime without asking the user first.
+func WithoutPrompt() Option {
+	return func(c *ensureConfig) {
+		c.prompt = false
+	}
+}
+
+// WithOwnerWindow makes the prompt modal to the given window.
+func WithOwnerWindow(owner uintptr) Option {
+	return func(c *ensureConfig) {
+		c.owner = owner
+	}
+}
+
+// WithMessages sets the text of the prompts. Blank strings are taken from DefaultMessages.
+func WithMessages(messages Messages) Option {
+	return func(c *ensureConfig) {
+		c.messages = &messages
+	}
+}
+
+// WithProgressDialog shows a progress window while the runtime downloads and installs.
+// Pressing Cancel in the window aborts the install.
+func WithProgressDialog() Option {
+	return func(c *ensureConfig) {
+		c.progress = true
+	}
+}
+
+// WithSilentInstall runs the installer without any installer UI.
+func WithSilentInstall() Option {
+	return func(c *ensureConfig) {
+		c.installOptions.Silent = true
+	}
+}
+
+// WithoutVerification skips checking the runtime is installed once the installer has finished.
+func WithoutVerification() Option {
+	return func(c *ensureConfig) {
+		c.verify = false
+	}
+}
+
+// WithEmbeddedBootstrapper installs using the bootstrapper embedded in this package rather than downloading it.
+func WithEmbeddedBootstrapper() Option {
+	return func(c *ensureConfig) {
+		c.useEmbedded = true
+	}
+}
+
+// WithInstallOptions sets the options used to run the installer.
+func WithInstallOptions(options InstallOptions) Option {
+	return func(c *ensureConfig) {
+		silent := c.installOptions.Silent
+		c.installOptions = options
+		c.installOptions.Silent = c.installOptions.Silent || silent
+	}
+}
@@ -0,0 +1,95 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+	"errors"
+)
+
+// EnsureInstalled makes sure a runtime at least as new as minVersion is installed.
+// If it isn't, the user is prompted, the runtime is installed and the install is verified.
+// Returns nil if an adequate runtime is installed at the end. Otherwise an *EnsureError is returned
+// recording the stage that failed. If the user declines to install, the error wraps ErrUserDeclined.
+func EnsureInstalled(minVersion string, opts ...Option) error {
+	return EnsureInstalledWithContext(context.Background(), minVersion, opts...)
+}
+
+// EnsureInstalledWithContext is the same as EnsureInstalled but the install is aborted if the context
+// is cancelled or times out.
+func EnsureInstalledWithContext(ctx context.Context, minVersion string, opts ...Option) error {
+	config := &ensureConfig{
+		prompt: true,
+		verify: true,
+	}
+	for _, opt := range opts {
+		opt(config)
+	}
+
+	status, err := Status(minVersion)
+	if err != nil {
+		return &EnsureError{Stage: StageDetect, Err: err}
+	}
+	if status == StatusInstalledOK || status == StatusInstalledNewer {
+		return nil
+	}
+
+	messages := DefaultMessages()
+	if config.messages != nil {
+		messages = config.messages.withDefaults(messages)
+	}
+	if config.prompt {
+		message := messages.MissingRuntime
+		if status == StatusInstalledTooOld {
+			message = messages.OutdatedRuntime
+		}
+		confirmed, err := ConfirmWithOwner(config.owner, message, messages.Title)
+		if err != nil {
+			return &EnsureError{Stage: StagePrompt, Err: err}
+		}
+		if !confirmed {
+			return &EnsureError{Stage: StagePrompt, Err: ErrUserDeclined}
+		}
+	}
+
+	if config.progress {
+		var dialog *ProgressDialog
+		dialog, ctx, err = ShowProgressDialog(ctx, config.owner, messages.Title)
+		if err != nil {
+			return &EnsureError{Stage: StageInstall, Err: err}
+		}
+		defer dialog.Close()
+		config.installOptions = dialog.reportTo(config.installOptions, messages)
+	}
+
+	if !config.verify {
+		config.installOptions.SkipVerification = true
+	}
+	var installed bool
+	if config.useEmbedded {
+		installed, err = InstallUsingEmbeddedBootstrapperWithContext(ctx, &config.installOptions)
+	} else {
+		installed, err = InstallUsingBootstrapperWithContext(ctx, &config.installOptions)
+	}
+	if err == nil && !installed {
+		err = errors.New("the installer did not complete successfully")
+	}
+	if err != nil {
+		if config.prompt && errors.Is(err, ErrDownloadFailed) {
+			_ = ErrorWithOwner(config.owner, messages.DownloadFailed, messages.Title)
+		}
+		return &EnsureError{Stage: StageInstall, Err: err}
+	}
+
+	if config.verify {
+		if !waitForPendingUpdateTasks(ctx, pendingTaskTimeout) {
+			return &EnsureError{Stage: StageVerify, Err: errors.New("timed out waiting for EdgeUpdate to finish")}
+		}
+		err = MustBeInstalled(minVersion)
+		if err != nil {
+			return &EnsureError{Stage: StageVerify, Err: err}
+		}
+	}
+	return nil
+}
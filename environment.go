@@ -0,0 +1,28 @@
+package webview2runtime
+
+// The environment variables read by WebView2Loader.dll when an app creates a WebView2 environment.
+const (
+	envBrowserExecutableFolder    = "WEBVIEW2_BROWSER_EXECUTABLE_FOLDER"
+	envUserDataFolder             = "WEBVIEW2_USER_DATA_FOLDER"
+	envAdditionalBrowserArguments = "WEBVIEW2_ADDITIONAL_BROWSER_ARGUMENTS"
+	envReleaseChannels            = "WEBVIEW2_RELEASE_CHANNELS"
+	envReleaseChannelPreference   = "WEBVIEW2_RELEASE_CHANNEL_PREFERENCE"
+)
+
+// EnvironmentConfig is the configuration an app should use when creating a WebView2 environment.
+// Each field can be passed to CreateCoreWebView2EnvironmentWithOptions, or set in the environment
+// of the process using Apply for apps whose WebView2 wrapper does not expose them.
+type EnvironmentConfig struct {
+	// BrowserExecutableFolder is the fixed version runtime to use, or blank to use an evergreen runtime.
+	BrowserExecutableFolder string
+	// UserDataFolder is where the runtime keeps its data, or blank for the default next to the executable.
+	UserDataFolder string
+	// AdditionalBrowserArguments are extra command line arguments passed to the runtime.
+	AdditionalBrowserArguments []string
+	// ReleaseChannels limits the channels the loader searches for an evergreen runtime.
+	// All channels are searched if empty.
+	ReleaseChannels []Channel
+	// PreferLeastStable makes the loader search the least stable channel first, eg to test an app
+	// against Canary on machines that also have the stable runtime.
+	PreferLeastStable bool
+}
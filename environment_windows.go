@@ -0,0 +1,80 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"golang.org/x/sys/windows"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// RecommendedEnvironment returns the recommended configuration for the given app. If a fixed version
+// runtime has been deployed to fixedVersionFolder using DeployFixedVersion, it is used as the
+// BrowserExecutableFolder, otherwise the evergreen runtime is used. If appName is not blank, the
+// UserDataFolder is the one given by RecommendedUserDataFolder.
+// Returns an error if the deployment record could not be read or the app name is invalid.
+func RecommendedEnvironment(appName string, fixedVersionFolder string) (*EnvironmentConfig, error) {
+	config := &EnvironmentConfig{}
+	if fixedVersionFolder != "" {
+		runtime, err := GetDeployedFixedVersion(fixedVersionFolder)
+		if err != nil {
+			return nil, err
+		}
+		if runtime != nil {
+			config.BrowserExecutableFolder = runtime.Folder
+		}
+	}
+	if appName != "" {
+		folder, err := RecommendedUserDataFolder(appName)
+		if err != nil {
+			return nil, err
+		}
+		config.UserDataFolder = folder
+	}
+	return config, nil
+}
+
+// Variables returns the configuration as the WEBVIEW2_* environment variables that the loader reads.
+// Fields that are not set are left out.
+func (e *EnvironmentConfig) Variables() map[string]string {
+	variables := map[string]string{}
+	if e.BrowserExecutableFolder != "" {
+		variables[envBrowserExecutableFolder] = e.BrowserExecutableFolder
+	}
+	if e.UserDataFolder != "" {
+		variables[envUserDataFolder] = e.UserDataFolder
+	}
+	if len(e.AdditionalBrowserArguments) > 0 {
+		args := make([]string, len(e.AdditionalBrowserArguments))
+		for i, arg := range e.AdditionalBrowserArguments {
+			args[i] = windows.EscapeArg(arg)
+		}
+		variables[envAdditionalBrowserArguments] = strings.Join(args, " ")
+	}
+	if len(e.ReleaseChannels) > 0 {
+		// The loader numbers the channels the same way as Channel
+		channels := make([]string, len(e.ReleaseChannels))
+		for i, channel := range e.ReleaseChannels {
+			channels[i] = strconv.Itoa(int(channel))
+		}
+		variables[envReleaseChannels] = strings.Join(channels, ",")
+	}
+	if e.PreferLeastStable {
+		variables[envReleaseChannelPreference] = "1"
+	}
+	return variables
+}
+
+// Apply sets the variables returned by Variables in the environment of this process, so that
+// WebView2 environments created afterwards use the configuration.
+func (e *EnvironmentConfig) Apply() error {
+	for name, value := range e.Variables() {
+		err := os.Setenv(name, value)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package webview2runtime
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrElevationRequired is returned when the installer needs administrator rights but
+	// the options do not allow it to be elevated.
+	ErrElevationRequired = errors.New("the installer requires elevation")
+	// ErrElevationDeclined is returned when the user refuses the UAC prompt to elevate the installer.
+	ErrElevationDeclined = errors.New("elevation of the installer was declined")
+	// ErrNotInstalled is returned when the webview2 runtime is required but not installed.
+	ErrNotInstalled = errors.New("webview2 runtime is not installed")
+	// ErrDownloadFailed is matched by a DownloadError using errors.Is.
+	ErrDownloadFailed = errors.New("download failed")
+	// ErrInstallerExit is matched by an InstallerExitError using errors.Is.
+	ErrInstallerExit = errors.New("installer failed")
+	// ErrRegistryAccess is matched by a RegistryError using errors.Is.
+	ErrRegistryAccess = errors.New("unable to access the registry")
+	// ErrRuntimeFilesMissing is matched by a MissingFilesError using errors.Is.
+	ErrRuntimeFilesMissing = errors.New("webview2 runtime files are missing")
+	// ErrUnknownSDKVersion is returned when the minimum runtime of a WebView2 SDK version is not known.
+	ErrUnknownSDKVersion = errors.New("unknown webview2 sdk version")
+	// ErrInstallTimeout is matched by an InstallTimeoutError using errors.Is.
+	ErrInstallTimeout = errors.New("the installer did not finish in time")
+	// ErrUnsupportedPlatform is returned on platforms other than Windows, where the runtime does not exist.
+	ErrUnsupportedPlatform = errors.New("the webview2 runtime is only supported on windows")
+)
+
+// DownloadError is returned when a download fails.
+// StatusCode is the HTTP status returned by the server, or 0 if no response was received.
+type DownloadError struct {
+	URL        string
+	StatusCode int
+	Err        error
+}
+
+func (e *DownloadError) Error() string {
+	if e.StatusCode != 0 {
+		return fmt.Sprintf("download of %s failed with HTTP status %d", e.URL, e.StatusCode)
+	}
+	return fmt.Sprintf("download of %s failed: %v", e.URL, e.Err)
+}
+
+func (e *DownloadError) Unwrap() error { return e.Err }
+
+// Is returns true if target is ErrDownloadFailed.
+func (e *DownloadError) Is(target error) bool { return target == ErrDownloadFailed }
+
+// InstallerExitError is returned when the installer exits with a code indicating failure.
+type InstallerExitError struct {
+	ExitCode uint32
+}
+
+func (e *InstallerExitError) Error() string {
+	return fmt.Sprintf("installer exited with code 0x%08X: %s", e.ExitCode, ExitCodeMessage(int(e.ExitCode)))
+}
+
+// Is returns true if target is ErrInstallerExit.
+func (e *InstallerExitError) Is(target error) bool { return target == ErrInstallerExit }
+
+// InstallTimeoutError is returned when the installer is killed because it did not finish within
+// the InstallTimeout. It records the state of the machine when the installer was killed.
+type InstallTimeoutError struct {
+	Timeout time.Duration
+	// InstalledVersion is the version of the runtime that was installed when the installer was killed, if any.
+	InstalledVersion string
+	// PendingTasks are the EdgeUpdate scheduled tasks that were still pending, which may show EdgeUpdate is wedged.
+	PendingTasks []string
+}
+
+func (e *InstallTimeoutError) Error() string {
+	message := fmt.Sprintf("the installer did not finish within %s", e.Timeout)
+	if e.InstalledVersion != "" {
+		message += fmt.Sprintf(", version %s is installed", e.InstalledVersion)
+	}
+	if len(e.PendingTasks) > 0 {
+		message += fmt.Sprintf(", pending EdgeUpdate tasks: %s", strings.Join(e.PendingTasks, ", "))
+	}
+	return message
+}
+
+// Is returns true if target is ErrInstallTimeout.
+func (e *InstallTimeoutError) Is(target error) bool { return target == ErrInstallTimeout }
+
+// RegistryError is returned when a registry key exists but could not be read.
+type RegistryError struct {
+	Key string
+	Err error
+}
+
+func (e *RegistryError) Error() string {
+	return fmt.Sprintf("unable to read %s: %v", e.Key, e.Err)
+}
+
+func (e *RegistryError) Unwrap() error { return e.Err }
+
+// Is returns true if target is ErrRegistryAccess.
+func (e *RegistryError) Is(target error) bool { return target == ErrRegistryAccess }
+
+// MissingFilesError is returned when a runtime is registered as installed but its files are missing from disk.
+type MissingFilesError struct {
+	Version string
+	// Missing lists the expected files or folders that could not be found.
+	Missing []string
+}
+
+func (e *MissingFilesError) Error() string {
+	return fmt.Sprintf("webview2 runtime %s is registered but files are missing: %s", e.Version, strings.Join(e.Missing, ", "))
+}
+
+// Is returns true if target is ErrRuntimeFilesMissing.
+func (e *MissingFilesError) Is(target error) bool { return target == ErrRuntimeFilesMissing }
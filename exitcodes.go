@@ -0,0 +1,119 @@
+package webview2runtime
+
+import (
+	"fmt"
+)
+
+const (
+	exitCodeSuccess        = 0
+	exitCodeRebootRequired = 3010       // ERROR_SUCCESS_REBOOT_REQUIRED
+	exitCodeRebootStarted  = 1641       // ERROR_SUCCESS_REBOOT_INITIATED
+	exitCodeAlreadyExists  = 0x80040c01 // An equal or newer version is already installed
+)
+
+// Reason is the decoded meaning of an installer exit code.
+type Reason int
+
+const (
+	// ReasonUnknown means the exit code was not recognised, or the installer did not run.
+	ReasonUnknown Reason = iota
+	// ReasonSuccess means the install completed successfully.
+	ReasonSuccess
+	// ReasonRebootRequired means the install completed but a reboot is needed to finish it.
+	ReasonRebootRequired
+	// ReasonAlreadyInstalled means an equal or newer version of the runtime is already installed.
+	ReasonAlreadyInstalled
+	// ReasonCancelled means the install was cancelled, eg by the user.
+	ReasonCancelled
+	// ReasonAdminRequired means administrator rights are needed to install.
+	ReasonAdminRequired
+	// ReasonInstallInProgress means another install is already running.
+	ReasonInstallInProgress
+	// ReasonDiskFull means there is not enough disk space to install.
+	ReasonDiskFull
+	// ReasonNetwork means the installer could not download the runtime.
+	ReasonNetwork
+	// ReasonFailed means the installer reported a general failure.
+	ReasonFailed
+	// ReasonBlockedByPolicy means group policy does not allow the runtime to be installed.
+	ReasonBlockedByPolicy
+)
+
+func (r Reason) String() string {
+	switch r {
+	case ReasonSuccess:
+		return "success"
+	case ReasonRebootRequired:
+		return "reboot required"
+	case ReasonAlreadyInstalled:
+		return "already installed"
+	case ReasonCancelled:
+		return "cancelled"
+	case ReasonAdminRequired:
+		return "admin required"
+	case ReasonInstallInProgress:
+		return "install in progress"
+	case ReasonDiskFull:
+		return "disk full"
+	case ReasonNetwork:
+		return "network failure"
+	case ReasonFailed:
+		return "failed"
+	case ReasonBlockedByPolicy:
+		return "blocked by policy"
+	}
+	return "unknown"
+}
+
+// knownExitCode is the description and Reason of an installer exit code.
+type knownExitCode struct {
+	message string
+	reason  Reason
+}
+
+// exitCodes describes the exit codes commonly returned by the bootstrapper and standalone installers.
+// HRESULT codes are stored as their unsigned 32-bit value.
+var exitCodes = map[uint32]knownExitCode{
+	exitCodeSuccess:        {"The installation completed successfully", ReasonSuccess},
+	exitCodeRebootRequired: {"The installation completed successfully but a reboot is required", ReasonRebootRequired},
+	exitCodeRebootStarted:  {"The installation completed successfully and a reboot has been started", ReasonRebootRequired},
+	exitCodeAlreadyExists:  {"An equal or newer version of the runtime is already installed", ReasonAlreadyInstalled},
+	1602:                   {"The installation was cancelled by the user", ReasonCancelled},
+	1603:                   {"A fatal error occurred during the installation", ReasonFailed},
+	1618:                   {"Another installation is already in progress", ReasonInstallInProgress},
+	0x80040812:             {"Installing the runtime has been disabled by group policy", ReasonBlockedByPolicy},
+	0x80040902:             {"The installer failed", ReasonFailed},
+	0x80070005:             {"Access denied: administrator rights are required", ReasonAdminRequired},
+	0x80070070:             {"There is not enough disk space to complete the installation", ReasonDiskFull},
+	0x80070422:             {"The EdgeUpdate service is disabled", ReasonFailed},
+	0x800704c7:             {"The installation was cancelled", ReasonCancelled},
+	0x80070643:             {"A fatal error occurred during the installation", ReasonFailed},
+	0x80070652:             {"Another installation is already in progress", ReasonInstallInProgress},
+	0x80072ee2:             {"The download timed out", ReasonNetwork},
+	0x80072ee7:             {"The download server could not be resolved. Check the network connection", ReasonNetwork},
+	0x80072efd:             {"The download server could not be reached. Check the network connection", ReasonNetwork},
+	0x80072efe:             {"The connection to the download server was closed. Check the network connection", ReasonNetwork},
+	0x80072f8f:             {"A secure connection to the download server could not be made. Check the system clock", ReasonNetwork},
+}
+
+// exitCodeReason returns the Reason for the given exit code.
+func exitCodeReason(code uint32) Reason {
+	return exitCodes[code].reason
+}
+
+// ExitCodeMessage returns a human readable description of an installer exit code.
+// HRESULT codes may be given either as a negative int or as their unsigned value.
+func ExitCodeMessage(code int) string {
+	known, ok := exitCodes[uint32(code)]
+	if !ok {
+		return fmt.Sprintf("Unknown installer exit code %d (0x%08X)", code, uint32(code))
+	}
+	return known.message
+}
+
+// DecodeInstallerExitCode returns the Reason and an English description of an installer exit code,
+// eg 0x80070005 decodes to ReasonAdminRequired. Codes that are not recognised decode to ReasonUnknown.
+// HRESULT codes may be given either as a negative int or as their unsigned value.
+func DecodeInstallerExitCode(code int) (Reason, string) {
+	return exitCodeReason(uint32(code)), ExitCodeMessage(code)
+}
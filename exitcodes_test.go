@@ -0,0 +1,82 @@
+package webview2runtime
+
+import (
+	"testing"
+)
+
+func TestDecodeInstallerExitCode(t *testing.T) {
+	tests := []struct {
+		code    uint32
+		reason  Reason
+		message string
+	}{
+		{0, ReasonSuccess, "The installation completed successfully"},
+		{3010, ReasonRebootRequired, "The installation completed successfully but a reboot is required"},
+		{1641, ReasonRebootRequired, "The installation completed successfully and a reboot has been started"},
+		{1602, ReasonCancelled, "The installation was cancelled by the user"},
+		{1603, ReasonFailed, "A fatal error occurred during the installation"},
+		{1618, ReasonInstallInProgress, "Another installation is already in progress"},
+		{0x80040c01, ReasonAlreadyInstalled, "An equal or newer version of the runtime is already installed"},
+		{0x80040812, ReasonBlockedByPolicy, "Installing the runtime has been disabled by group policy"},
+		{0x80040902, ReasonFailed, "The installer failed"},
+		{0x80070005, ReasonAdminRequired, "Access denied: administrator rights are required"},
+		{0x80070070, ReasonDiskFull, "There is not enough disk space to complete the installation"},
+		{0x80070422, ReasonFailed, "The EdgeUpdate service is disabled"},
+		{0x800704c7, ReasonCancelled, "The installation was cancelled"},
+		{0x80070643, ReasonFailed, "A fatal error occurred during the installation"},
+		{0x80070652, ReasonInstallInProgress, "Another installation is already in progress"},
+		{0x80072ee2, ReasonNetwork, "The download timed out"},
+		{0x80072ee7, ReasonNetwork, "The download server could not be resolved. Check the network connection"},
+		{0x80072efd, ReasonNetwork, "The download server could not be reached. Check the network connection"},
+		{0x80072efe, ReasonNetwork, "The connection to the download server was closed. Check the network connection"},
+		{0x80072f8f, ReasonNetwork, "A secure connection to the download server could not be made. Check the system clock"},
+		{42, ReasonUnknown, "Unknown installer exit code 42 (0x0000002A)"},
+	}
+	for _, test := range tests {
+		// HRESULTs are accepted both as their unsigned value and as a negative int
+		for _, code := range []int{int(test.code), int(int32(test.code))} {
+			reason, message := DecodeInstallerExitCode(code)
+			if reason != test.reason {
+				t.Errorf("DecodeInstallerExitCode(%d) reason = %s, want %s", code, reason, test.reason)
+			}
+			if message != test.message {
+				t.Errorf("DecodeInstallerExitCode(%d) message = %q, want %q", code, message, test.message)
+			}
+			if got := ExitCodeMessage(code); got != test.message {
+				t.Errorf("ExitCodeMessage(%d) = %q, want %q", code, got, test.message)
+			}
+		}
+	}
+}
+
+func TestExitCodeMessageUnknownHRESULT(t *testing.T) {
+	want := "Unknown installer exit code -2147467259 (0x80004005)"
+	if got := ExitCodeMessage(-2147467259); got != want {
+		t.Errorf("ExitCodeMessage() = %q, want %q", got, want)
+	}
+}
+
+func TestReasonString(t *testing.T) {
+	tests := []struct {
+		reason Reason
+		want   string
+	}{
+		{ReasonUnknown, "unknown"},
+		{ReasonSuccess, "success"},
+		{ReasonRebootRequired, "reboot required"},
+		{ReasonAlreadyInstalled, "already installed"},
+		{ReasonCancelled, "cancelled"},
+		{ReasonAdminRequired, "admin required"},
+		{ReasonInstallInProgress, "install in progress"},
+		{ReasonDiskFull, "disk full"},
+		{ReasonNetwork, "network failure"},
+		{ReasonFailed, "failed"},
+		{ReasonBlockedByPolicy, "blocked by policy"},
+		{Reason(100), "unknown"},
+	}
+	for _, test := range tests {
+		if got := test.reason.String(); got != test.want {
+			t.Errorf("Reason(%d).String() = %q, want %q", int(test.reason), got, test.want)
+		}
+	}
+}
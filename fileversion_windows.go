@@ -0,0 +1,80 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"fmt"
+	"golang.org/x/sys/windows"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	modversion                  = syscall.NewLazyDLL("version.dll")
+	procGetFileVersionInfoSizeW = modversion.NewProc("GetFileVersionInfoSizeW")
+	procGetFileVersionInfoW     = modversion.NewProc("GetFileVersionInfoW")
+	procVerQueryValueW          = modversion.NewProc("VerQueryValueW")
+)
+
+// VS_FIXEDFILEINFO struct
+type _VS_FIXEDFILEINFO struct {
+	dwSignature        uint32
+	dwStrucVersion     uint32
+	dwFileVersionMS    uint32
+	dwFileVersionLS    uint32
+	dwProductVersionMS uint32
+	dwProductVersionLS uint32
+	dwFileFlagsMask    uint32
+	dwFileFlags        uint32
+	dwFileOS           uint32
+	dwFileType         uint32
+	dwFileSubtype      uint32
+	dwFileDateMS       uint32
+	dwFileDateLS       uint32
+}
+
+// errNoVersionResource is returned by getFileVersion if the file has no version resource.
+var errNoVersionResource = fmt.Errorf("file has no version resource")
+
+// getFileVersion returns the product version from the version resource of the given file.
+func getFileVersion(path string) (string, error) {
+	pathUTF16, err := syscall.UTF16PtrFromString(path)
+	if err != nil {
+		return "", err
+	}
+	size, _, err := procGetFileVersionInfoSizeW.Call(uintptr(unsafe.Pointer(pathUTF16)), 0)
+	if size == 0 {
+		if err == windows.ERROR_RESOURCE_DATA_NOT_FOUND || err == windows.ERROR_RESOURCE_TYPE_NOT_FOUND {
+			return "", errNoVersionResource
+		}
+		return "", err
+	}
+	data := make([]byte, size)
+	ret, _, err := procGetFileVersionInfoW.Call(uintptr(unsafe.Pointer(pathUTF16)), 0, size, uintptr(unsafe.Pointer(&data[0])))
+	if ret == 0 {
+		return "", err
+	}
+
+	root, err := syscall.UTF16PtrFromString(`\`)
+	if err != nil {
+		return "", err
+	}
+	var fixedInfo *_VS_FIXEDFILEINFO
+	var length uint32
+	ret, _, _ = procVerQueryValueW.Call(
+		uintptr(unsafe.Pointer(&data[0])),
+		uintptr(unsafe.Pointer(root)),
+		uintptr(unsafe.Pointer(&fixedInfo)),
+		uintptr(unsafe.Pointer(&length)),
+	)
+	if ret == 0 || length == 0 || fixedInfo == nil {
+		return "", errNoVersionResource
+	}
+	return fmt.Sprintf("%d.%d.%d.%d",
+		fixedInfo.dwProductVersionMS>>16,
+		fixedInfo.dwProductVersionMS&0xffff,
+		fixedInfo.dwProductVersionLS>>16,
+		fixedInfo.dwProductVersionLS&0xffff,
+	), nil
+}
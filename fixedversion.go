@@ -0,0 +1,18 @@
+package webview2runtime
+
+import (
+	"time"
+)
+
+// FixedVersionRuntime is a fixed version runtime deployed by DeployFixedVersion.
+type FixedVersionRuntime struct {
+	// Folder contains msedgewebview2.exe and should be passed as browserExecutableFolder
+	// to CreateCoreWebView2EnvironmentWithOptions.
+	Folder string `json:"folder"`
+	// Version is the version of the runtime.
+	Version string `json:"version"`
+	// Source is the archive the runtime was deployed from.
+	Source string `json:"source"`
+	// Deployed is when the runtime was deployed.
+	Deployed time.Time `json:"deployed"`
+}
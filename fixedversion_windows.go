@@ -0,0 +1,248 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// fixedVersionRecord is the file written to a deployment folder recording what was deployed.
+const fixedVersionRecord = "webview2runtime.json"
+
+// fixedVersionFolderPattern matches the folder name Microsoft uses inside fixed version archives,
+// eg `Microsoft.WebView2.FixedVersionRuntime.91.0.864.59.x64`.
+var fixedVersionFolderPattern = regexp.MustCompile(`^Microsoft\.WebView2\.FixedVersionRuntime\.(\d+\.\d+\.\d+\.\d+)\.`)
+
+// DeployFixedVersion extracts a fixed version runtime archive (.cab or .zip) into the destination folder,
+// validates it contains a working runtime and records the deployed version in the folder.
+// A relative destination is treated as relative to the folder containing the executable. Runtimes
+// deployed there earlier are left alone, unless the archive contains a folder of the same name.
+// Returns the deployed runtime, whose Folder is suitable for passing as browserExecutableFolder.
+func DeployFixedVersion(archive string, destination string) (*FixedVersionRuntime, error) {
+	destination, err := appRelativePath(destination)
+	if err != nil {
+		return nil, err
+	}
+	err = os.MkdirAll(destination, 0755)
+	if err != nil {
+		return nil, err
+	}
+
+	// The archive is extracted to its own folder first, so a runtime deployed earlier is never mistaken for it
+	staging, err := os.MkdirTemp(destination, ".deploy-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(staging)
+	switch strings.ToLower(filepath.Ext(archive)) {
+	case ".cab":
+		err = extractCab(archive, staging)
+	case ".zip":
+		err = extractZip(archive, staging)
+	default:
+		err = fmt.Errorf("unsupported archive type: %s", archive)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	extracted, err := findRuntimeFolder(staging)
+	if err != nil {
+		return nil, err
+	}
+	relative, err := filepath.Rel(staging, extracted)
+	if err != nil {
+		return nil, err
+	}
+	err = moveEntries(staging, destination)
+	if err != nil {
+		return nil, err
+	}
+	folder := filepath.Join(destination, relative)
+	version, err := fixedVersionFolderVersion(folder)
+	if err != nil {
+		return nil, err
+	}
+	result := &FixedVersionRuntime{
+		Folder:   folder,
+		Version:  version,
+		Source:   archive,
+		Deployed: time.Now(),
+	}
+	data, err := json.MarshalIndent(result, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	err = os.WriteFile(filepath.Join(destination, fixedVersionRecord), data, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetDeployedFixedVersion returns the fixed version runtime previously deployed to the destination folder.
+// A relative destination is treated as relative to the folder containing the executable.
+// Returns nil if nothing has been deployed there.
+func GetDeployedFixedVersion(destination string) (*FixedVersionRuntime, error) {
+	destination, err := appRelativePath(destination)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(filepath.Join(destination, fixedVersionRecord))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var result FixedVersionRuntime
+	err = json.Unmarshal(data, &result)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deployment record: %w", err)
+	}
+	return &result, nil
+}
+
+// appRelativePath resolves a path relative to the folder containing the executable.
+func appRelativePath(path string) (string, error) {
+	if filepath.IsAbs(path) {
+		return path, nil
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(exe), path), nil
+}
+
+// extractCab extracts a cab file using the expand tool that ships with Windows.
+func extractCab(archive string, destination string) error {
+	cmd := exec.Command("expand", archive, "-F:*", destination)
+	cmd.SysProcAttr = hiddenWindow()
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("unable to extract %s: %w: %s", archive, err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// extractZip extracts a zip file, rejecting entries that would be written outside the destination.
+func extractZip(archive string, destination string) error {
+	reader, err := zip.OpenReader(archive)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	for _, file := range reader.File {
+		target := filepath.Join(destination, file.Name)
+		if !strings.HasPrefix(target, filepath.Clean(destination)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid path in archive: %s", file.Name)
+		}
+		if file.FileInfo().IsDir() {
+			err = os.MkdirAll(target, 0755)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		err = extractZipFile(file, target)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractZipFile(file *zip.File, target string) error {
+	err := os.MkdirAll(filepath.Dir(target), 0755)
+	if err != nil {
+		return err
+	}
+	in, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	if err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// moveEntries moves everything in the source folder into the destination folder,
+// replacing any files or folders of the same name.
+func moveEntries(source string, destination string) error {
+	entries, err := os.ReadDir(source)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		target := filepath.Join(destination, entry.Name())
+		err = os.RemoveAll(target)
+		if err != nil {
+			return fmt.Errorf("unable to replace %s: %w", target, err)
+		}
+		err = os.Rename(filepath.Join(source, entry.Name()), target)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findRuntimeFolder returns the folder within the destination that contains a valid runtime.
+// Archives either contain the runtime files directly or within a single versioned folder.
+func findRuntimeFolder(destination string) (string, error) {
+	candidates := []string{destination}
+	entries, err := os.ReadDir(destination)
+	if err != nil {
+		return "", err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			candidates = append(candidates, filepath.Join(destination, entry.Name()))
+		}
+	}
+	for _, candidate := range candidates {
+		if !exists(filepath.Join(candidate, runtimeExecutable)) {
+			continue
+		}
+		for _, file := range runtimeFiles {
+			if !exists(filepath.Join(candidate, file)) {
+				return "", fmt.Errorf("fixed version runtime in %s is missing %s", candidate, file)
+			}
+		}
+		return candidate, nil
+	}
+	return "", fmt.Errorf("no fixed version runtime found in %s", destination)
+}
+
+// fixedVersionFolderVersion returns the version of the runtime in the given folder.
+func fixedVersionFolderVersion(folder string) (string, error) {
+	version, err := getFileVersion(filepath.Join(folder, runtimeExecutable))
+	if err == nil {
+		return version, nil
+	}
+	match := fixedVersionFolderPattern.FindStringSubmatch(filepath.Base(folder))
+	if match != nil {
+		return match[1], nil
+	}
+	return "", fmt.Errorf("unable to determine the version of the runtime in %s: %w", folder, err)
+}
@@ -0,0 +1,35 @@
+package webview2runtime
+
+// RuntimeHealth summarises the signals used to judge the state of the runtime on this machine.
+type RuntimeHealth struct {
+	// InstalledVersion is the version reported by WebView2Loader.dll. Blank if not installed.
+	InstalledVersion string
+	// Conflicts are registrations that disagree with each other.
+	Conflicts []Conflict
+	// Orphaned are registrations whose files are missing.
+	Orphaned []OrphanedRuntime
+
+	// UserDataFolders are EBWebView user data folders found for this app.
+	// This is a soft signal only: it shows the runtime has been used by the app at some point,
+	// not that it is currently installed or working.
+	UserDataFolders []string
+}
+
+// HealthReport is the result of validating an installation of the runtime.
+type HealthReport struct {
+	// Info is the installation that was validated.
+	Info *Info
+	// Missing lists the expected files or folders that could not be found.
+	Missing []string
+	// Unsigned lists the runtime binaries that do not have a valid signature from Microsoft.
+	Unsigned []string
+	// DiskVersion is the file version of msedgewebview2.exe. Blank if it could not be read.
+	DiskVersion string
+	// Problems describes everything found wrong with the installation.
+	Problems []string
+}
+
+// Healthy returns true if no problems were found.
+func (r *HealthReport) Healthy() bool {
+	return len(r.Problems) == 0
+}
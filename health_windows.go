@@ -0,0 +1,110 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// CheckRuntimeHealth gathers the signals describing the state of the runtime.
+// Returns an error if the registry could not be read.
+func CheckRuntimeHealth() (*RuntimeHealth, error) {
+	conflicts, err := DetectConflicts()
+	if err != nil {
+		return nil, err
+	}
+	orphaned, err := DetectOrphanedRuntime()
+	if err != nil {
+		return nil, err
+	}
+	return &RuntimeHealth{
+		InstalledVersion: GetInstalledVersion(),
+		Conflicts:        conflicts,
+		Orphaned:         orphaned,
+		UserDataFolders:  findUserDataFolders(),
+	}, nil
+}
+
+// Repair re-runs the bootstrapper to repair the installation. See RepairInstallation.
+// The options may be nil.
+func (r *HealthReport) Repair(ctx context.Context, options *RepairOptions) (*InstallResult, error) {
+	return RepairInstallation(ctx, options)
+}
+
+// ValidateInstallation checks the files of the given installation: the version folder exists,
+// msedgewebview2.exe, msedge.dll and msedge_elf.dll are present and signed by Microsoft, and the
+// version of msedgewebview2.exe matches the registered version.
+// Returns an error if info is nil.
+func ValidateInstallation(info *Info) (*HealthReport, error) {
+	if info == nil {
+		return nil, ErrNotInstalled
+	}
+	report := &HealthReport{Info: info}
+	report.Missing = missingRuntimeFiles(info)
+	for _, missing := range report.Missing {
+		report.Problems = append(report.Problems, fmt.Sprintf("%s is missing", missing))
+	}
+
+	versionFolder := filepath.Join(info.Location, info.Version)
+	for _, file := range runtimeFiles {
+		path := filepath.Join(versionFolder, file)
+		if !exists(path) {
+			continue
+		}
+		_, err := VerifySignature(path)
+		if err != nil {
+			report.Unsigned = append(report.Unsigned, path)
+			report.Problems = append(report.Problems, err.Error())
+		}
+	}
+
+	executable := filepath.Join(versionFolder, runtimeExecutable)
+	if exists(executable) {
+		version, err := getFileVersion(executable)
+		switch {
+		case err != nil:
+			report.Problems = append(report.Problems, fmt.Sprintf("unable to read the version of %s: %s", executable, err))
+		case CompareVersions(version, info.Version) != 0:
+			report.DiskVersion = version
+			report.Problems = append(report.Problems, fmt.Sprintf("%s is version %s but version %s is registered", executable, version, info.Version))
+		default:
+			report.DiskVersion = version
+		}
+	}
+	return report, nil
+}
+
+// findUserDataFolders returns the EBWebView user data folders that exist for the current executable.
+// The candidates are the default location next to the executable (`<app>.exe.WebView2`) and the
+// common locations under %LOCALAPPDATA%.
+func findUserDataFolders() []string {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil
+	}
+	exeName := filepath.Base(exe)
+	appName := strings.TrimSuffix(exeName, filepath.Ext(exeName))
+	candidates := []string{
+		filepath.Join(exe+".WebView2", "EBWebView"),
+	}
+	localAppData := os.Getenv("LOCALAPPDATA")
+	if localAppData != "" {
+		candidates = append(candidates,
+			filepath.Join(localAppData, appName, "EBWebView"),
+			filepath.Join(localAppData, exeName+".WebView2", "EBWebView"),
+		)
+	}
+
+	var result []string
+	for _, candidate := range candidates {
+		if exists(candidate) {
+			result = append(result, candidate)
+		}
+	}
+	return result
+}
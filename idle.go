@@ -0,0 +1,59 @@
+package webview2runtime
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrRuntimeInUse is returned when the runtime is still in use after waiting for it to become idle.
+var ErrRuntimeInUse = errors.New("the webview2 runtime is in use")
+
+// defaultIdleTimeout is how long WaitForIdle waits by default.
+const defaultIdleTimeout = 30 * time.Second
+
+// InUsePolicy determines what happens when the runtime is in use before it is repaired or uninstalled.
+type InUsePolicy int
+
+const (
+	// InUseIgnore runs the installer or uninstaller regardless. This is the default.
+	InUseIgnore InUsePolicy = iota
+	// InUseWait waits for the apps using the runtime to exit.
+	InUseWait
+	// InUsePrompt asks the user to close the apps using the runtime.
+	InUsePrompt
+	// InUseTerminate terminates the runtime processes, which may lose unsaved work in the apps using them.
+	InUseTerminate
+)
+
+// String returns the name of the policy.
+func (p InUsePolicy) String() string {
+	switch p {
+	case InUseIgnore:
+		return "ignore"
+	case InUseWait:
+		return "wait"
+	case InUsePrompt:
+		return "prompt"
+	case InUseTerminate:
+		return "terminate"
+	}
+	return "unknown"
+}
+
+// InUseOptions configure WaitForIdle.
+type InUseOptions struct {
+	Policy InUsePolicy
+	// Timeout is how long to wait for the processes to exit. Defaults to 30 seconds.
+	Timeout time.Duration
+	// Owner is the window the prompt is modal to when the Policy is InUsePrompt.
+	Owner uintptr
+	// Messages are the text of the prompt. Blank strings are taken from DefaultMessages.
+	Messages Messages
+}
+
+func (o InUseOptions) timeout() time.Duration {
+	if o.Timeout <= 0 {
+		return defaultIdleTimeout
+	}
+	return o.Timeout
+}
@@ -0,0 +1,101 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+	"fmt"
+	"golang.org/x/sys/windows"
+	"sort"
+	"strings"
+	"time"
+)
+
+// WaitForIdle checks whether any msedgewebview2.exe processes are running from this installation and,
+// according to the policy, waits for them to exit, asks the user to close the apps using them or
+// terminates them. Returns an error wrapping ErrRuntimeInUse if processes are still running
+// at the end, eg because the timeout expired or the user cancelled the prompt.
+func (i *Info) WaitForIdle(ctx context.Context, options InUseOptions) error {
+	if options.Policy == InUseIgnore {
+		return nil
+	}
+	processes, err := i.Processes()
+	if err != nil || len(processes) == 0 {
+		return err
+	}
+	logEvent("runtime in use", "version", i.Version, "processes", len(processes), "policy", options.Policy)
+
+	switch options.Policy {
+	case InUsePrompt:
+		messages := options.Messages.withDefaults(DefaultMessages())
+		for len(processes) > 0 {
+			caption := messages.RuntimeInUse + "\n\n" + strings.Join(hostExecutables(processes), "\n")
+			button, err := Prompt(options.Owner, caption, messages.Title, ButtonsRetryCancel, IconWarning)
+			if err != nil {
+				return err
+			}
+			if button != ButtonRetry {
+				return fmt.Errorf("%w: the user cancelled closing %d processes", ErrRuntimeInUse, len(processes))
+			}
+			processes, err = i.Processes()
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case InUseTerminate:
+		for _, process := range processes {
+			err := terminateProcess(process.PID)
+			if err != nil {
+				logEvent("unable to terminate process", "pid", process.PID, "error", err)
+			}
+		}
+	}
+	return i.waitForProcessesToExit(ctx, options.timeout())
+}
+
+// waitForProcessesToExit polls until no runtime processes are running from this installation.
+func (i *Info) waitForProcessesToExit(ctx context.Context, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		processes, err := i.Processes()
+		if err != nil || len(processes) == 0 {
+			return err
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("%w: %d processes are still running after %s", ErrRuntimeInUse, len(processes), timeout)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(processPollInterval):
+		}
+	}
+}
+
+// hostExecutables returns the sorted names of the apps hosting the processes.
+func hostExecutables(processes []ProcessInfo) []string {
+	seen := map[string]bool{}
+	var hosts []string
+	for _, process := range processes {
+		host := process.HostExecutable
+		if host == "" || seen[strings.ToLower(host)] {
+			continue
+		}
+		seen[strings.ToLower(host)] = true
+		hosts = append(hosts, host)
+	}
+	sort.Strings(hosts)
+	return hosts
+}
+
+// terminateProcess terminates the process with the given PID.
+func terminateProcess(pid uint32) error {
+	process, err := windows.OpenProcess(windows.PROCESS_TERMINATE, false, pid)
+	if err != nil {
+		return err
+	}
+	defer windows.CloseHandle(process)
+	return windows.TerminateProcess(process, 1)
+}
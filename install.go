@@ -0,0 +1,126 @@
+package webview2runtime
+
+import (
+	"io"
+	"net/http"
+	"time"
+)
+
+// InstallOption configures Install.
+type InstallOption func(*installConfig)
+
+type installConfig struct {
+	options      InstallOptions
+	source       installSource
+	bootstrapper io.Reader
+	standalone   string
+}
+
+// UsingOptions sets all the options used to run the installer, replacing any set by earlier
+// InstallOptions, so it is best given first. The options may be nil.
+func UsingOptions(options *InstallOptions) InstallOption {
+	return func(c *installConfig) {
+		c.options = InstallOptions{}
+		if options != nil {
+			c.options = *options
+		}
+	}
+}
+
+// FromBootstrapper installs using the bootstrapper read from the given reader rather than downloading it.
+func FromBootstrapper(bootstrapper io.Reader) InstallOption {
+	return func(c *installConfig) {
+		c.source = sourceProvided
+		c.bootstrapper = bootstrapper
+	}
+}
+
+// FromStandaloneInstaller installs using the offline standalone installer at the given path.
+// The installer is always run silently and is never removed.
+func FromStandaloneInstaller(path string) InstallOption {
+	return func(c *installConfig) {
+		c.source = sourceStandalone
+		c.standalone = path
+	}
+}
+
+// FromURLs downloads the bootstrapper from the given URLs, eg an internal mirror. See InstallOptions.BootstrapperURLs.
+func FromURLs(urls ...string) InstallOption {
+	return func(c *installConfig) {
+		c.options.BootstrapperURLs = urls
+	}
+}
+
+// Silently runs the installer without any installer UI.
+func Silently() InstallOption {
+	return func(c *installConfig) {
+		c.options.Silent = true
+	}
+}
+
+// Hidden stops the installer showing a window, even when it is not silent. See InstallOptions.HideWindow.
+func Hidden() InstallOption {
+	return func(c *installConfig) {
+		c.options.HideWindow = true
+	}
+}
+
+// ForScope installs the runtime per-machine or per-user. See InstallOptions.Scope.
+func ForScope(scope InstallScope) InstallOption {
+	return func(c *installConfig) {
+		c.options.Scope = scope
+	}
+}
+
+// ExpectingVersion fails the install unless the given version is installed once the installer has finished.
+// If allowNewer is true, any version at least as new is accepted.
+func ExpectingVersion(version string, allowNewer bool) InstallOption {
+	return func(c *installConfig) {
+		c.options.ExpectedVersion = version
+		c.options.AllowNewerVersion = allowNewer
+	}
+}
+
+// ThroughProxy downloads the bootstrapper using the HTTP proxy with the given URL. See InstallOptions.Proxy.
+func ThroughProxy(proxy string) InstallOption {
+	return func(c *installConfig) {
+		c.options.Proxy = proxy
+	}
+}
+
+// UsingHTTPClient downloads the bootstrapper using the given client.
+func UsingHTTPClient(client *http.Client) InstallOption {
+	return func(c *installConfig) {
+		c.options.HTTPClient = client
+	}
+}
+
+// WithDownloadTimeout limits how long each attempt to download the bootstrapper may take.
+func WithDownloadTimeout(timeout time.Duration) InstallOption {
+	return func(c *installConfig) {
+		c.options.DownloadTimeout = timeout
+	}
+}
+
+// WithInstallTimeout kills the installer if it runs for longer than the given timeout. See InstallOptions.InstallTimeout.
+func WithInstallTimeout(timeout time.Duration) InstallOption {
+	return func(c *installConfig) {
+		c.options.InstallTimeout = timeout
+	}
+}
+
+// ReportingProgress calls the given functions as the install downloads and moves through each Phase.
+// Either may be nil.
+func ReportingProgress(progress func(downloaded int64, total int64), phaseChanged func(phase Phase)) InstallOption {
+	return func(c *installConfig) {
+		c.options.DownloadProgress = progress
+		c.options.PhaseChanged = phaseChanged
+	}
+}
+
+// AsDryRun returns the Plan of the install without doing anything. See InstallOptions.DryRun.
+func AsDryRun() InstallOption {
+	return func(c *installConfig) {
+		c.options.DryRun = true
+	}
+}
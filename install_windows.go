@@ -0,0 +1,32 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"bytes"
+	"context"
+)
+
+// FromEmbeddedBootstrapper installs using the bootstrapper embedded in this package rather than downloading it.
+func FromEmbeddedBootstrapper() InstallOption {
+	return FromBootstrapper(bytes.NewReader(setupexe))
+}
+
+// Install installs the runtime configured by the given options. By default the bootstrapper is
+// downloaded from Microsoft and run with the default InstallOptions. The installer is killed if the
+// context is cancelled or times out. The result is never nil.
+// The InstallUsing functions are shorthands for calling Install with the matching options.
+func Install(ctx context.Context, opts ...InstallOption) (*InstallResult, error) {
+	config := &installConfig{}
+	for _, opt := range opts {
+		opt(config)
+	}
+	switch config.source {
+	case sourceProvided:
+		return installProvidedBootstrapper(ctx, config.bootstrapper, &config.options)
+	case sourceStandalone:
+		return installStandalone(ctx, config.standalone, &config.options)
+	}
+	return installDownloadedBootstrapper(ctx, &config.options)
+}
@@ -0,0 +1,49 @@
+package webview2runtime
+
+import (
+	"errors"
+)
+
+// InstallResult describes the outcome of running an installer.
+type InstallResult struct {
+	// Installer is the path of the installer that was run.
+	Installer string
+	// ExitCode is the exit code of the installer. Use ExitCodeMessage to describe it.
+	ExitCode uint32
+	// Reason is the decoded meaning of ExitCode. It is ReasonUnknown if the installer did not run.
+	Reason Reason
+	// RebootRequired is true if the installer reported that a reboot is needed to finish the install.
+	RebootRequired bool
+	// Scope is where the runtime is installed once the install has succeeded.
+	Scope Scope
+	// Success is true if the install succeeded.
+	Success bool
+	// Error is the reason the install failed, if it did.
+	Error error
+	// CleanupError is the first problem found removing the installer and log files according to the
+	// CleanupPolicy. It does not affect Success, as the runtime is installed regardless.
+	CleanupError error
+	// SpawnedProcesses are the installer and the processes it started, where they could be tracked.
+	SpawnedProcesses []SpawnedProcess
+	// Output is the end of what the installer wrote to stdout and stderr. It is blank if the installer
+	// was elevated, or a CommandHook redirected the output.
+	Output string
+	// Logs are the ends of the installer and EdgeUpdate logs, keyed by path, collected if the install failed.
+	Logs map[string]string
+	// Plan is what the install would have done when the DryRun option is set.
+	Plan *Plan
+}
+
+// setReason sets Reason and RebootRequired from the exit code, or the error if the installer could not be run.
+func (r *InstallResult) setReason(err error) {
+	var exitErr *InstallerExitError
+	switch {
+	case err == nil, errors.As(err, &exitErr):
+		r.Reason = exitCodeReason(r.ExitCode)
+	case errors.Is(err, ErrElevationDeclined):
+		r.Reason = ReasonCancelled
+	case errors.Is(err, ErrElevationRequired):
+		r.Reason = ReasonAdminRequired
+	}
+	r.RebootRequired = r.Reason == ReasonRebootRequired
+}
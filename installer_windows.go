@@ -0,0 +1,341 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"golang.org/x/sys/windows"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+// installerRun describes an installer to run.
+type installerRun struct {
+	// path is the path of the installer.
+	path string
+	// temporary is true if the installer was written by this package and may be cleaned up.
+	temporary bool
+}
+
+// install runs the given installer, verifies the result, then cleans up according to the cleanup policy.
+// The OnComplete callback is called last, and any error it returns becomes the result of the install.
+// The returned result is never nil.
+func install(ctx context.Context, run installerRun, options *InstallOptions) (*InstallResult, error) {
+	start := time.Now()
+	result := &InstallResult{Installer: run.path}
+	err := options.checkInstaller(run.path)
+	if err == nil {
+		options.reportPhase(PhaseInstalling)
+		result.ExitCode, err = runInstaller(ctx, run, options.collectInto(result))
+		result.setReason(err)
+		options.reportEvent(InstallEvent{Type: EventInstallerExited, Result: result, Err: err})
+	}
+	if err == nil {
+		options.reportPhase(PhaseVerifying)
+		err = options.verify(ctx)
+		if err == nil {
+			options.reportEvent(InstallEvent{Type: EventVerified, Version: GetInstalledVersion(), Result: result})
+		}
+	}
+	result.Success = err == nil
+	result.Error = err
+	if result.Success {
+		if info, _ := GetInstallation(); info != nil {
+			result.Scope = info.Scope
+		}
+	} else {
+		result.Logs, _ = TailInstallerLogs(installerLogTail)
+	}
+
+	files := options.runLogPaths(start.Truncate(time.Second))
+	if run.temporary {
+		files = append(files, run.path)
+	}
+	if len(files) > 0 {
+		options.reportPhase(PhaseCleaningUp)
+		result.CleanupError = options.cleanup(result.Success, files...)
+		if result.CleanupError != nil {
+			logEvent("cleanup failed", "error", result.CleanupError)
+		}
+	}
+
+	options.reportPhase(PhaseComplete)
+	reportInstall(result, start)
+	onComplete := options.onComplete()
+	if onComplete != nil {
+		hookErr := onComplete(result)
+		if hookErr != nil {
+			result.Success = false
+			return result, hookErr
+		}
+	}
+	return result, err
+}
+
+// reportInstall reports the result of an install that started at the given time to the Telemetry.
+func reportInstall(result *InstallResult, start time.Time) {
+	reason := result.Reason
+	if !result.Success && (reason == ReasonUnknown || reason == ReasonSuccess) {
+		reason = ReasonFailed
+	}
+	getTelemetry().InstallResult(InstallTelemetry{
+		Success:  result.Success,
+		ExitCode: result.ExitCode,
+		Reason:   reason,
+		Scope:    result.Scope,
+		Duration: time.Since(start),
+		Err:      result.Error,
+	})
+}
+
+// runInstaller runs the installer and waits for it to exit.
+// If the process is already elevated, elevation is disabled, or the caller has given a CommandHook,
+// the installer is started directly using exec.Cmd. Otherwise it is started using ShellExecuteEx
+// with the "runas" verb so that the user is prompted for elevation.
+// The installer, and any processes it started, are killed if the context is cancelled or the
+// InstallTimeout expires.
+// Returns the exit code of the installer, with an error if it indicates failure.
+func runInstaller(ctx context.Context, run installerRun, options *InstallOptions) (uint32, error) {
+	runCtx := ctx
+	timeout := options.installTimeout()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	exitCode, err := launch(runCtx, run.path, options.arguments(), options)
+	if options.scope() == InstallScopeAuto && (errors.Is(err, ErrElevationDeclined) || errors.Is(err, ErrElevationRequired)) {
+		if ok, _ := CanInstallPerUser(); ok {
+			logEvent("installing per-user", "path", run.path, "reason", err)
+			perUser := options.perUser()
+			exitCode, err = launch(runCtx, run.path, perUser.arguments(), perUser)
+		}
+	}
+	if err != nil && ctx.Err() == nil && runCtx.Err() == context.DeadlineExceeded {
+		err = &InstallTimeoutError{Timeout: timeout, InstalledVersion: GetInstalledVersion(), PendingTasks: pendingTaskNames()}
+	}
+	if err != nil {
+		logEvent("installer failed", "path", run.path, "error", err)
+		return 0, err
+	}
+	logEvent("installer exited", "path", run.path, "exitCode", fmt.Sprintf("0x%08X", exitCode), "reason", exitCodeReason(exitCode))
+	switch exitCode {
+	case exitCodeSuccess, exitCodeRebootRequired, exitCodeRebootStarted, exitCodeAlreadyExists:
+		return exitCode, nil
+	}
+	return exitCode, &InstallerExitError{ExitCode: exitCode}
+}
+
+// launch runs the program with the given arguments using the Runner option, and returns its exit code.
+// The detection cache is invalidated once the program has finished.
+func launch(ctx context.Context, program string, args []string, options *InstallOptions) (uint32, error) {
+	defer InvalidateDetectionCache()
+	return options.processRunner().Run(ctx, program, args)
+}
+
+// startProcess starts the program with the given arguments, elevating it if needed, and returns its exit code.
+// See runInstaller for how the program is started.
+func startProcess(ctx context.Context, program string, args []string, options *InstallOptions) (uint32, error) {
+	if IsElevated() || options.elevation() == ElevationNever || options.commandHook() != nil {
+		logEvent("starting", "path", program, "args", strings.Join(args, " "), "method", "exec")
+		return execInstaller(ctx, program, args, options)
+	}
+	logEvent("starting", "path", program, "args", strings.Join(args, " "), "method", "runas")
+	return shellExecuteInstaller(ctx, program, args, options)
+}
+
+// hiddenWindow returns the process attributes that stop a command showing a window or flashing a console.
+func hiddenWindow() *syscall.SysProcAttr {
+	return &syscall.SysProcAttr{
+		HideWindow:    true,
+		CreationFlags: windows.CREATE_NO_WINDOW,
+	}
+}
+
+// installerCommand returns the command that runs the installer, hiding its window if the options require it.
+func installerCommand(ctx context.Context, installer string, args []string, options *InstallOptions) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, installer, args...)
+	cmd.Dir = os.Getenv("TMP")
+	if options.hideWindow() {
+		cmd.SysProcAttr = hiddenWindow()
+	}
+	return cmd
+}
+
+// execInstaller starts the installer using exec.Cmd and returns its exit code.
+// If the installer requires elevation, it is relaunched using ShellExecuteEx unless elevation is disabled.
+func execInstaller(ctx context.Context, installer string, args []string, options *InstallOptions) (uint32, error) {
+	cmd := installerCommand(ctx, installer, args, options)
+	hook := options.commandHook()
+	if hook != nil {
+		err := hook(cmd)
+		if err != nil {
+			return 0, fmt.Errorf("command hook failed: %w", err)
+		}
+	}
+	var output *tailBuffer
+	if cmd.Stdout == nil && cmd.Stderr == nil {
+		output = &tailBuffer{limit: installerOutputLimit}
+		cmd.Stdout = output
+		cmd.Stderr = output
+	}
+	job, err := startInJob(cmd)
+	if err == nil {
+		err = waitForCommand(ctx, cmd, job, options)
+		if output != nil && output.Len() > 0 {
+			options.reportOutput(output.String())
+		}
+	}
+	if errors.Is(err, windows.ERROR_ELEVATION_REQUIRED) {
+		if options.elevation() == ElevationNever {
+			return 0, ErrElevationRequired
+		}
+		logEvent("elevation required", "path", installer, "method", "runas")
+		return shellExecuteInstaller(ctx, installer, args, options)
+	}
+	if ctx.Err() != nil {
+		return 0, ctx.Err()
+	}
+	var exitError *exec.ExitError
+	if errors.As(err, &exitError) {
+		return uint32(exitError.ExitCode()), nil
+	}
+	return 0, err
+}
+
+// showCommand returns how ShellExecuteEx should show the installer window.
+func showCommand(options *InstallOptions) int {
+	if options.hideWindow() {
+		return syscall.SW_HIDE
+	}
+	return syscall.SW_NORMAL
+}
+
+// shellExecuteInstaller starts the installer elevated using ShellExecuteEx and returns its exit code.
+func shellExecuteInstaller(ctx context.Context, installer string, args []string, options *InstallOptions) (uint32, error) {
+	// Quote the arguments the same way exec.Cmd does
+	escaped := make([]string, len(args))
+	for i, arg := range args {
+		escaped[i] = windows.EscapeArg(arg)
+	}
+	parameters := strings.Join(escaped, " ")
+	exitCode, err := shellExecuteAndWaitForExit(ctx, options, 0, "runas", installer, parameters, os.Getenv("TMP"), showCommand(options))
+	if errors.Is(err, windows.ERROR_CANCELLED) {
+		return 0, ErrElevationDeclined
+	}
+	return exitCode, err
+}
+
+// shellExecuteAndWaitForExit is a version of ShellExecuteAndWait that returns the exit code of the process.
+// The process, and the processes it started if they could be put in a job, are terminated if the context
+// is cancelled before it exits. The processes that were spawned are reported to the options.
+func shellExecuteAndWaitForExit(ctx context.Context, options *InstallOptions, hwnd hwnd, lpOperation, lpFile, lpParameters, lpDirectory string, nShowCmd int) (uint32, error) {
+	i := &_SHELLEXECUTEINFO{
+		fMask: _SEE_MASK_NOCLOSEPROCESS,
+		hwnd:  hwnd,
+		nShow: nShowCmd,
+	}
+	i.cbSize = dword(unsafe.Sizeof(*i))
+	for _, field := range []struct {
+		value  string
+		target *lpctstr
+	}{
+		{lpOperation, &i.lpVerb},
+		{lpFile, &i.lpFile},
+		{lpParameters, &i.lpParameters},
+		{lpDirectory, &i.lpDirectory},
+	} {
+		if len(field.value) == 0 {
+			continue
+		}
+		ptr, err := toUTF16(field.value)
+		if err != nil {
+			return 0, err
+		}
+		*field.target = ptr
+	}
+
+	ret, _, err := procShellExecuteEx.Call(uintptr(unsafe.Pointer(i)))
+	if ret == 0 {
+		return 0, os.NewSyscallError("ShellExecuteEx", err)
+	}
+	if i.hProcess == 0 {
+		return 0, nil
+	}
+	process := windows.Handle(i.hProcess)
+	defer windows.CloseHandle(process)
+	job, err := newProcessJob(process)
+	if err != nil {
+		// Elevated processes cannot always be assigned to a job, in which case only the process is killed
+		logEvent("unable to create job", "error", err)
+	}
+	defer job.close(options)
+
+	err = waitForProcess(ctx, process, job)
+	if err != nil {
+		return 0, err
+	}
+	var exitCode uint32
+	err = windows.GetExitCodeProcess(process, &exitCode)
+	if err != nil {
+		return 0, os.NewSyscallError("GetExitCodeProcess", err)
+	}
+	return exitCode, nil
+}
+
+// processPollInterval is how often a process is checked while waiting for it to exit.
+const processPollInterval = 100 * time.Millisecond
+
+// waitForCommand waits for the started command to exit, recording the processes in its job, which
+// may be nil. If the context is cancelled, the command and every process in the job are killed.
+func waitForCommand(ctx context.Context, cmd *exec.Cmd, job *processJob, options *InstallOptions) error {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		for {
+			job.record()
+			select {
+			case <-ctx.Done():
+				job.terminate()
+				return
+			case <-done:
+				return
+			case <-time.After(processPollInterval):
+			}
+		}
+	}()
+	err := cmd.Wait()
+	close(done)
+	<-stopped
+	job.close(options)
+	return err
+}
+
+// waitForProcess waits for the process to exit. If the context is cancelled, the process and
+// every process in the job, which may be nil, are killed.
+func waitForProcess(ctx context.Context, process windows.Handle, job *processJob) error {
+	for {
+		job.record()
+		event, err := windows.WaitForSingleObject(process, uint32(processPollInterval/time.Millisecond))
+		switch event {
+		case windows.WAIT_OBJECT_0:
+			return nil
+		case uint32(windows.WAIT_TIMEOUT):
+		default:
+			return os.NewSyscallError("WaitForSingleObject", err)
+		}
+		if ctx.Err() != nil {
+			job.terminate()
+			_ = windows.TerminateProcess(process, 1)
+			return ctx.Err()
+		}
+	}
+}
@@ -0,0 +1,166 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+	"golang.org/x/sys/windows"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestInstallerCommandHideWindow(t *testing.T) {
+	tests := []struct {
+		name    string
+		options *InstallOptions
+		hidden  bool
+	}{
+		{"nil options", nil, false},
+		{"defaults", &InstallOptions{}, false},
+		{"hide window", &InstallOptions{HideWindow: true}, true},
+		{"silent", &InstallOptions{Silent: true}, true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := installerCommand(context.Background(), `C:\Temp\MicrosoftEdgeWebview2Setup.exe`, nil, test.options)
+			if !test.hidden {
+				if cmd.SysProcAttr != nil {
+					t.Errorf("SysProcAttr = %+v, want nil", cmd.SysProcAttr)
+				}
+			} else if cmd.SysProcAttr == nil || !cmd.SysProcAttr.HideWindow || cmd.SysProcAttr.CreationFlags&windows.CREATE_NO_WINDOW == 0 {
+				t.Errorf("SysProcAttr = %+v, want HideWindow and CREATE_NO_WINDOW", cmd.SysProcAttr)
+			}
+
+			want := syscall.SW_NORMAL
+			if test.hidden {
+				want = syscall.SW_HIDE
+			}
+			if got := showCommand(test.options); got != want {
+				t.Errorf("showCommand() = %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestHiddenOption(t *testing.T) {
+	var config installConfig
+	Hidden()(&config)
+	if !config.options.hideWindow() {
+		t.Error("Hidden() did not set HideWindow")
+	}
+}
+
+// fakeInstaller is a ProcessRunner that exits with the given code, optionally writing the installer
+// log to the temp directory as the installer does when given the LogLevel switches.
+type fakeInstaller struct {
+	exitCode uint32
+	writeLog bool
+}
+
+func (r fakeInstaller) Run(ctx context.Context, program string, args []string) (uint32, error) {
+	if !r.writeLog {
+		return r.exitCode, nil
+	}
+	err := os.WriteFile(filepath.Join(os.TempDir(), "msedge_installer.log"), []byte("installing"), 0o600)
+	return r.exitCode, err
+}
+
+func TestInstallCleanup(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   CleanupPolicy
+		logLevel LogLevel
+		exitCode uint32
+		// holdInstaller keeps the installer open so it cannot be removed.
+		holdInstaller    bool
+		installerRemoved bool
+		logRemoved       bool
+		cleanupErr       bool
+	}{
+		{name: "always after success", policy: CleanupAlways, logLevel: LogLevelNormal, installerRemoved: true, logRemoved: true},
+		{name: "always after failure", policy: CleanupAlways, logLevel: LogLevelVerbose, exitCode: 1603, installerRemoved: true, logRemoved: true},
+		{name: "on success after success", policy: CleanupOnSuccess, logLevel: LogLevelNormal, installerRemoved: true, logRemoved: true},
+		{name: "on success after failure", policy: CleanupOnSuccess, logLevel: LogLevelNormal, exitCode: 1603},
+		{name: "never", policy: CleanupNever, logLevel: LogLevelNormal},
+		{name: "logging off", policy: CleanupAlways, logLevel: LogLevelNone, installerRemoved: true},
+		{name: "installer in use", policy: CleanupAlways, logLevel: LogLevelNormal, holdInstaller: true, logRemoved: true, cleanupErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			temp := t.TempDir()
+			setenv(t, "TMP", temp)
+			setenv(t, "TEMP", temp)
+			installer := filepath.Join(temp, defaultInstallerFilename)
+			err := os.WriteFile(installer, []byte("installer"), 0o600)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if test.holdInstaller {
+				file, err := os.Open(installer)
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer file.Close()
+			}
+			options := &InstallOptions{
+				CleanupPolicy: test.policy,
+				LogLevel:      test.logLevel,
+				Runner:        fakeInstaller{exitCode: test.exitCode, writeLog: true},
+				VerifyTimeout: time.Millisecond,
+				detector:      &Detector{Registry: runtimeRegistry("120.0.2210.91"), Loader: noLoader, Architecture: ArchX64},
+				pendingTasks:  finishingTasks(0, nil),
+			}
+
+			result, err := install(context.Background(), installerRun{path: installer, temporary: true}, options)
+			if wantSuccess := test.exitCode == 0; result.Success != wantSuccess || (err == nil) != wantSuccess {
+				t.Errorf("install() = %t, %v, want success %t", result.Success, err, wantSuccess)
+			}
+			if (result.CleanupError != nil) != test.cleanupErr {
+				t.Errorf("CleanupError = %v, want error %t", result.CleanupError, test.cleanupErr)
+			}
+			for path, want := range map[string]bool{
+				installer: test.installerRemoved,
+				filepath.Join(temp, "msedge_installer.log"): test.logRemoved,
+			} {
+				_, err := os.Stat(path)
+				if removed := os.IsNotExist(err); removed != want {
+					t.Errorf("%s removed = %t, want %t", filepath.Base(path), removed, want)
+				}
+			}
+		})
+	}
+}
+
+func TestInstallCleanupKeepsOlderLogs(t *testing.T) {
+	temp := t.TempDir()
+	setenv(t, "TMP", temp)
+	setenv(t, "TEMP", temp)
+	log := filepath.Join(temp, "msedge_installer.log")
+	err := os.WriteFile(log, []byte("an earlier install"), 0o600)
+	if err == nil {
+		earlier := time.Now().Add(-time.Hour)
+		err = os.Chtimes(log, earlier, earlier)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	options := &InstallOptions{
+		LogLevel:      LogLevelNormal,
+		Runner:        fakeInstaller{},
+		VerifyTimeout: time.Millisecond,
+		detector:      &Detector{Registry: runtimeRegistry("120.0.2210.91"), Loader: noLoader, Architecture: ArchX64},
+		pendingTasks:  finishingTasks(0, nil),
+	}
+	_, err = install(context.Background(), installerRun{path: filepath.Join(temp, defaultInstallerFilename)}, options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(log); err != nil {
+		t.Errorf("the log of an earlier install was removed: %v", err)
+	}
+}
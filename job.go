@@ -0,0 +1,9 @@
+package webview2runtime
+
+// SpawnedProcess is a process started while running an installer: the installer itself or a
+// process it started, eg MicrosoftEdgeUpdate.exe.
+type SpawnedProcess struct {
+	PID uint32 `json:"pid"`
+	// Path is the path of the executable, or blank if it could not be read.
+	Path string `json:"path"`
+}
@@ -0,0 +1,181 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"golang.org/x/sys/windows"
+	"os"
+	"os/exec"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// jobObjectBasicProcessIDList is the JobObjectBasicProcessIdList information class.
+const jobObjectBasicProcessIDList = 3
+
+// maxJobProcesses is the most processes read from a job at once.
+const maxJobProcesses = 64
+
+// jobObjectBasicProcessIDListInfo is JOBOBJECT_BASIC_PROCESS_ID_LIST with room for maxJobProcesses.
+type jobObjectBasicProcessIDListInfo struct {
+	NumberOfAssignedProcesses uint32
+	NumberOfProcessIdsInList  uint32
+	ProcessIDList             [maxJobProcesses]uintptr
+}
+
+var (
+	lastSpawnedLock sync.Mutex
+	lastSpawned     []SpawnedProcess
+)
+
+// lastSpawnedProcesses returns the processes spawned by the last program this package ran.
+func lastSpawnedProcesses() []SpawnedProcess {
+	lastSpawnedLock.Lock()
+	defer lastSpawnedLock.Unlock()
+	return append([]SpawnedProcess(nil), lastSpawned...)
+}
+
+// processJob is a job object holding a process and the processes it starts, so that they can all
+// be terminated together. The bootstrapper starts MicrosoftEdgeUpdate.exe, which would otherwise
+// keep running after the bootstrapper is killed.
+type processJob struct {
+	handle windows.Handle
+	// spawned are the processes seen in the job so far.
+	spawned []SpawnedProcess
+}
+
+// newProcessJob creates a job object and assigns the process to it.
+// Processes the process starts from then on are also in the job.
+func newProcessJob(process windows.Handle) (*processJob, error) {
+	handle, err := windows.CreateJobObject(nil, nil)
+	if err != nil {
+		return nil, os.NewSyscallError("CreateJobObject", err)
+	}
+	err = windows.AssignProcessToJobObject(handle, process)
+	if err != nil {
+		windows.CloseHandle(handle)
+		return nil, os.NewSyscallError("AssignProcessToJobObject", err)
+	}
+	return &processJob{handle: handle}, nil
+}
+
+// newProcessJobForPID is the same as newProcessJob but opens the process with the given ID.
+func newProcessJobForPID(pid int) (*processJob, error) {
+	process, err := windows.OpenProcess(windows.PROCESS_SET_QUOTA|windows.PROCESS_TERMINATE, false, uint32(pid))
+	if err != nil {
+		return nil, os.NewSyscallError("OpenProcess", err)
+	}
+	defer windows.CloseHandle(process)
+	return newProcessJob(process)
+}
+
+// startInJob starts the command in a new job object. The command is started suspended and only
+// resumed once it is in the job, so that no process it starts can escape the job.
+// If the job cannot be created the command is still started, and the returned job is nil.
+func startInJob(cmd *exec.Cmd) (*processJob, error) {
+	if cmd.SysProcAttr == nil {
+		cmd.SysProcAttr = &syscall.SysProcAttr{}
+	}
+	cmd.SysProcAttr.CreationFlags |= windows.CREATE_SUSPENDED
+	err := cmd.Start()
+	if err != nil {
+		return nil, err
+	}
+	job, jobErr := newProcessJobForPID(cmd.Process.Pid)
+	if jobErr != nil {
+		logEvent("unable to create job", "error", jobErr)
+	}
+	err = resumeProcess(uint32(cmd.Process.Pid))
+	if err != nil {
+		job.terminate()
+		job.close(nil)
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+		return nil, err
+	}
+	return job, nil
+}
+
+// resumeProcess resumes the threads of a process that was started suspended.
+func resumeProcess(pid uint32) error {
+	snapshot, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPTHREAD, 0)
+	if err != nil {
+		return os.NewSyscallError("CreateToolhelp32Snapshot", err)
+	}
+	defer windows.CloseHandle(snapshot)
+
+	entry := windows.ThreadEntry32{Size: uint32(unsafe.Sizeof(windows.ThreadEntry32{}))}
+	for err = windows.Thread32First(snapshot, &entry); err == nil; err = windows.Thread32Next(snapshot, &entry) {
+		if entry.OwnerProcessID != pid {
+			continue
+		}
+		thread, err := windows.OpenThread(windows.THREAD_SUSPEND_RESUME, false, entry.ThreadID)
+		if err != nil {
+			return os.NewSyscallError("OpenThread", err)
+		}
+		_, err = windows.ResumeThread(thread)
+		windows.CloseHandle(thread)
+		if err != nil {
+			return os.NewSyscallError("ResumeThread", err)
+		}
+	}
+	if err != windows.ERROR_NO_MORE_FILES {
+		return os.NewSyscallError("Thread32Next", err)
+	}
+	return nil
+}
+
+// record adds the processes currently in the job that have not been seen before to spawned.
+// A nil job does nothing.
+func (j *processJob) record() {
+	if j == nil {
+		return
+	}
+	var info jobObjectBasicProcessIDListInfo
+	err := windows.QueryInformationJobObject(j.handle, jobObjectBasicProcessIDList, uintptr(unsafe.Pointer(&info)), uint32(unsafe.Sizeof(info)), nil)
+	if err != nil && err != windows.ERROR_MORE_DATA {
+		return
+	}
+	count := info.NumberOfProcessIdsInList
+	if count > maxJobProcesses {
+		count = maxJobProcesses
+	}
+next:
+	for _, id := range info.ProcessIDList[:count] {
+		pid := uint32(id)
+		for _, process := range j.spawned {
+			if process.PID == pid {
+				continue next
+			}
+		}
+		path, _ := processImagePath(pid)
+		j.spawned = append(j.spawned, SpawnedProcess{PID: pid, Path: path})
+	}
+}
+
+// terminate kills every process in the job. A nil job does nothing.
+func (j *processJob) terminate() {
+	if j == nil {
+		return
+	}
+	err := windows.TerminateJobObject(j.handle, 1)
+	if err != nil {
+		logEvent("unable to terminate installer processes", "error", err)
+	}
+}
+
+// close closes the job object and reports the processes that were spawned in it.
+// The processes in the job keep running. A nil job does nothing.
+func (j *processJob) close(options *InstallOptions) {
+	if j == nil {
+		return
+	}
+	j.record()
+	windows.CloseHandle(j.handle)
+	lastSpawnedLock.Lock()
+	lastSpawned = j.spawned
+	lastSpawnedLock.Unlock()
+	options.reportSpawned(j.spawned)
+}
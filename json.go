@@ -0,0 +1,54 @@
+package webview2runtime
+
+import (
+	"encoding/json"
+)
+
+// infoJSON is the JSON form of Info. The field names are stable and safe to depend on.
+type infoJSON struct {
+	Version         string `json:"version"`
+	Name            string `json:"name"`
+	Location        string `json:"location"`
+	Architecture    string `json:"architecture"`
+	Channel         string `json:"channel"`
+	Scope           string `json:"scope"`
+	DetectionMethod string `json:"detectionMethod"`
+	SilentUninstall string `json:"silentUninstall,omitempty"`
+	RegistryVersion string `json:"registryVersion,omitempty"`
+	InstallDate     string `json:"installDate,omitempty"`
+	UpdatePolicy    string `json:"updatePolicy"`
+
+	RegisteredArchitecture string            `json:"registeredArchitecture,omitempty"`
+	Values                 map[string]string `json:"values,omitempty"`
+}
+
+// MarshalJSON encodes the installation with stable, camel case field names. The enums are encoded by
+// name and the architecture is read from msedgewebview2.exe, or is `unknown` if it could not be read.
+// The registered architecture and raw registry values are left out if there are none.
+func (i Info) MarshalJSON() ([]byte, error) {
+	arch, _ := i.Architecture()
+	var installDate string
+	if !i.InstallDate.IsZero() {
+		installDate = i.InstallDate.Format("2006-01-02")
+	}
+	var registeredArch string
+	if i.RegisteredArchitecture != ArchUnknown {
+		registeredArch = i.RegisteredArchitecture.String()
+	}
+	return json.Marshal(infoJSON{
+		Version:         i.Version,
+		Name:            i.Name,
+		Location:        i.Location,
+		Architecture:    arch.String(),
+		Channel:         i.Channel.String(),
+		Scope:           i.Scope.String(),
+		DetectionMethod: i.DetectionMethod.String(),
+		SilentUninstall: i.SilentUninstall,
+		RegistryVersion: i.RegistryVersion,
+		InstallDate:     installDate,
+		UpdatePolicy:    i.UpdatePolicy.String(),
+
+		RegisteredArchitecture: registeredArch,
+		Values:                 i.Values,
+	})
+}
@@ -0,0 +1,18 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"encoding/json"
+)
+
+// DetectJSON is the same as Detect but returns the installation as JSON.
+// The JSON is `null` if the runtime is not installed.
+func DetectJSON() ([]byte, error) {
+	info, err := Detect()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(info)
+}
@@ -0,0 +1,53 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"fmt"
+	"strings"
+)
+
+// installLanguages are the languages the installer can be run in, as accepted by the `lang` installer argument.
+var installLanguages = []string{
+	"af", "am", "ar", "as", "az", "bg", "bn-IN", "bs", "ca", "ca-ES-valencia", "cs", "cy", "da", "de",
+	"el", "en-GB", "en-US", "es", "es-419", "et", "eu", "fa", "fi", "fil", "fr", "fr-CA", "ga", "gd",
+	"gl", "gu", "he", "hi", "hr", "hu", "hy", "id", "is", "it", "ja", "ka", "kk", "km", "kn", "ko",
+	"kok", "lb", "lo", "lt", "lv", "mi", "mk", "ml", "mr", "ms", "mt", "nb", "ne", "nl", "nn", "or",
+	"pa", "pl", "pt-BR", "pt-PT", "quz", "ro", "ru", "sk", "sl", "sq", "sr-Cyrl-BA", "sr-Cyrl-RS",
+	"sr-Latn-RS", "sv", "ta", "te", "th", "tr", "tt", "ug", "uk", "ur", "uz", "vi", "zh-CN", "zh-TW",
+}
+
+// installLanguage returns the language as it is written in installLanguages.
+// Returns an error if the installer does not support the language.
+func installLanguage(language string) (string, error) {
+	for _, known := range installLanguages {
+		if strings.EqualFold(known, language) {
+			return known, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported install language: %s", language)
+}
+
+// installerTag returns the argument given to `/install` that chooses what the installer installs,
+// or a blank string if the installer should install the runtime as normal. A tag is needed whenever
+// the language, channel or scope is chosen, as `needsadmin` is what makes EdgeUpdate install per-user.
+func (o *InstallOptions) installerTag() string {
+	if o == nil || (o.Language == "" && o.TargetChannel == ChannelStable && o.scope() == InstallScopeAuto) {
+		return ""
+	}
+	guid, _ := channelGUID(o.TargetChannel)
+	needsAdmin := "prefers"
+	switch o.scope() {
+	case InstallScopeMachine:
+		needsAdmin = "true"
+	case InstallScopeUser:
+		needsAdmin = "false"
+	}
+	tag := fmt.Sprintf("appguid=%s&needsadmin=%s", guid, needsAdmin)
+	if o.Language != "" {
+		language, _ := installLanguage(o.Language)
+		tag += "&lang=" + language
+	}
+	return tag
+}
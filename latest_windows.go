@@ -0,0 +1,96 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// edgeUpdatesURL lists the current releases of each Edge channel.
+// The evergreen webview2 runtime tracks the Stable channel.
+const edgeUpdatesURL = `https://edgeupdates.microsoft.com/api/products`
+
+type edgeProduct struct {
+	Product  string
+	Releases []struct {
+		Platform       string
+		Architecture   string
+		ProductVersion string
+	}
+}
+
+// edgeProductNames are the names edgeUpdatesURL uses for each channel.
+var edgeProductNames = map[Channel]string{
+	ChannelStable: "Stable",
+	ChannelBeta:   "Beta",
+	ChannelDev:    "Dev",
+	ChannelCanary: "Canary",
+}
+
+// LatestAvailableVersion queries Microsoft for the version number of the latest evergreen runtime.
+// Nothing is downloaded or installed. The HTTP client set by SetHTTPClient is used.
+// Returns an error if Microsoft could not be reached or the response was not understood.
+func LatestAvailableVersion() (string, error) {
+	return QueryLatestVersion(context.Background(), ChannelStable)
+}
+
+// QueryLatestVersion queries Microsoft for the version number of the latest release of the given channel.
+// The evergreen runtime tracks ChannelStable. Nothing is downloaded or installed.
+// The HTTP client set by SetHTTPClient is used, and the request is aborted if the context is cancelled.
+// Returns an error if Microsoft could not be reached or the response was not understood.
+func QueryLatestVersion(ctx context.Context, channel Channel) (string, error) {
+	productName, ok := edgeProductNames[channel]
+	if !ok {
+		return "", fmt.Errorf("unknown channel: %d", int(channel))
+	}
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, edgeUpdatesURL, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := getHTTPClient().Do(request)
+	if err != nil {
+		return "", fmt.Errorf("unable to reach %s (are you offline?): %w", edgeUpdatesURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unable to query latest version: %s returned %s", edgeUpdatesURL, resp.Status)
+	}
+
+	var products []edgeProduct
+	err = json.NewDecoder(resp.Body).Decode(&products)
+	if err != nil {
+		return "", fmt.Errorf("unable to parse version metadata: %w", err)
+	}
+	return latestVersion(products, productName, processArchitecture().String())
+}
+
+// latestVersion returns the Windows version of the product for the given architecture, falling back to
+// any Windows release of the product if there is no release for the architecture.
+func latestVersion(products []edgeProduct, productName string, arch string) (string, error) {
+	for _, product := range products {
+		if product.Product != productName {
+			continue
+		}
+		fallback := ""
+		for _, release := range product.Releases {
+			if release.Platform != "Windows" || release.ProductVersion == "" {
+				continue
+			}
+			if release.Architecture == arch {
+				return release.ProductVersion, nil
+			}
+			if fallback == "" {
+				fallback = release.ProductVersion
+			}
+		}
+		if fallback != "" {
+			return fallback, nil
+		}
+	}
+	return "", fmt.Errorf("no %s windows release found in version metadata", strings.ToLower(productName))
+}
@@ -0,0 +1,314 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"golang.org/x/sys/windows"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+// loaderName is the file name of the WebView2 loader.
+const loaderName = "WebView2Loader.dll"
+
+var (
+	loaderPathLock     sync.Mutex
+	loaderPathOverride string
+	loaderBytes        []byte
+	// loader is the loader loaded using the current settings. It is replaced when they change.
+	loader = &cachedLoader{}
+)
+
+// SetLoaderPath sets the path of the WebView2Loader.dll used for detection, eg for apps that
+// extract the loader to their own folder. Passing a blank path restores the default, which is the
+// loader next to the executable, then the safe DLL search directories: System32 and any directories
+// added with AddDllDirectory, then the loader given to SetLoaderBytes. The current directory and PATH are never searched, so a planted
+// DLL cannot be loaded in place of the real loader.
+// The loader is loaded the first time it is needed and stays loaded. Calling SetLoaderPath or
+// SetLoaderBytes makes the next detection load it again using the new settings.
+func SetLoaderPath(path string) {
+	loaderPathLock.Lock()
+	defer loaderPathLock.Unlock()
+	loaderPathOverride = path
+	loader = &cachedLoader{}
+}
+
+// SetLoaderBytes provides a copy of WebView2Loader.dll, eg embedded in the app using go:embed, for
+// detection to use when no loader is found. The DLL is extracted to a private folder in the user's
+// local app data the first time it is needed. It must match the architecture of the process.
+// Passing nil removes the fallback.
+func SetLoaderBytes(dll []byte) {
+	loaderPathLock.Lock()
+	defer loaderPathLock.Unlock()
+	loaderBytes = dll
+	loader = &cachedLoader{}
+}
+
+func getLoaderBytes() []byte {
+	loaderPathLock.Lock()
+	defer loaderPathLock.Unlock()
+	return loaderBytes
+}
+
+func getLoaderPath() string {
+	loaderPathLock.Lock()
+	defer loaderPathLock.Unlock()
+	return loaderPathOverride
+}
+
+// cachedLoader loads the loader the first time it is needed and keeps it loaded, along with the
+// functions found in it. A loader that fails to load is not retried until the settings change.
+// The loader is never released, as other goroutines may still be calling into it.
+type cachedLoader struct {
+	once sync.Once
+	dll  *windows.DLL
+	err  error
+
+	procsLock sync.Mutex
+	procs     map[string]*windows.Proc
+}
+
+// getLoader returns the loader for the current settings, loading it if needed.
+func getLoader() (*windows.DLL, error) {
+	return currentLoader().load()
+}
+
+// findLoaderProc returns the named function of the loader for the current settings.
+func findLoaderProc(name string) (*windows.Proc, error) {
+	return currentLoader().proc(name)
+}
+
+func currentLoader() *cachedLoader {
+	loaderPathLock.Lock()
+	defer loaderPathLock.Unlock()
+	return loader
+}
+
+func (c *cachedLoader) load() (*windows.DLL, error) {
+	c.once.Do(func() {
+		c.dll, c.err = loadLoader()
+		if c.err == nil {
+			logEvent("loaded loader", "path", c.dll.Name)
+		}
+	})
+	return c.dll, c.err
+}
+
+func (c *cachedLoader) proc(name string) (*windows.Proc, error) {
+	dll, err := c.load()
+	if err != nil {
+		return nil, err
+	}
+	c.procsLock.Lock()
+	defer c.procsLock.Unlock()
+	if proc, ok := c.procs[name]; ok {
+		return proc, nil
+	}
+	proc, err := dll.FindProc(name)
+	if err != nil {
+		return nil, err
+	}
+	if c.procs == nil {
+		c.procs = map[string]*windows.Proc{}
+	}
+	c.procs[name] = proc
+	return proc, nil
+}
+
+// loadLoader loads WebView2Loader.dll from the path given to SetLoaderPath, or from the safe locations
+// described by SetLoaderPath. Use getLoader rather than calling this directly.
+func loadLoader() (*windows.DLL, error) {
+	if override := getLoaderPath(); override != "" {
+		path, err := filepath.Abs(override)
+		if err != nil {
+			return nil, err
+		}
+		return loadLoaderFrom(path)
+	}
+	executable, err := os.Executable()
+	if err == nil {
+		path := filepath.Join(filepath.Dir(executable), loaderName)
+		if _, err := os.Stat(path); err == nil {
+			return loadLoaderFrom(path)
+		}
+	}
+	handle, err := windows.LoadLibraryEx(loaderName, 0, windows.LOAD_LIBRARY_SEARCH_DEFAULT_DIRS)
+	if err == nil {
+		return &windows.DLL{Name: loaderName, Handle: handle}, nil
+	}
+	if dll := getLoaderBytes(); dll != nil {
+		path, extractErr := extractLoader(dll)
+		if extractErr != nil {
+			return nil, extractErr
+		}
+		arch, archErr := peArchitecture(path)
+		if archErr == nil && arch != processArchitecture() {
+			return nil, fmt.Errorf("the provided %s is %s but the process is %s", loaderName, arch, processArchitecture())
+		}
+		return loadLoaderFrom(path)
+	}
+	return nil, fmt.Errorf("unable to load %s: %w", loaderName, err)
+}
+
+// extractLoader writes the loader to a folder in the user's local app data named after its hash,
+// so different versions never overwrite each other. Returns the path of the extracted loader.
+// The loader is only written if it has not already been extracted.
+func extractLoader(dll []byte) (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(dll)
+	dir := filepath.Join(cacheDir, "webview2runtime", hex.EncodeToString(hash[:8]))
+	path := filepath.Join(dir, loaderName)
+	if info, err := os.Stat(path); err == nil && info.Size() == int64(len(dll)) {
+		return path, nil
+	}
+	err = os.MkdirAll(dir, 0755)
+	if err != nil {
+		return "", fmt.Errorf("unable to extract %s: %w", loaderName, err)
+	}
+	// Write to a temp file first so another process never loads a partially written loader
+	temp, err := os.CreateTemp(dir, "WebView2Loader-*.tmp")
+	if err != nil {
+		return "", fmt.Errorf("unable to extract %s: %w", loaderName, err)
+	}
+	_, err = temp.Write(dll)
+	closeErr := temp.Close()
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(temp.Name(), path)
+	}
+	if err != nil {
+		_ = os.Remove(temp.Name())
+		if _, statErr := os.Stat(path); statErr == nil {
+			// Another process extracted it first
+			return path, nil
+		}
+		return "", fmt.Errorf("unable to extract %s: %w", loaderName, err)
+	}
+	logEvent("extracted loader", "path", path)
+	return path, nil
+}
+
+// loadLoaderFrom loads the loader at the given absolute path. Its dependencies are loaded from
+// its own folder and System32. Windows 7 without KB2533623 does not support the search flags,
+// so the altered search path is used instead.
+func loadLoaderFrom(path string) (*windows.DLL, error) {
+	handle, err := windows.LoadLibraryEx(path, 0, windows.LOAD_LIBRARY_SEARCH_DLL_LOAD_DIR|windows.LOAD_LIBRARY_SEARCH_SYSTEM32)
+	if err == windows.ERROR_INVALID_PARAMETER {
+		handle, err = windows.LoadLibraryEx(path, 0, windows.LOAD_WITH_ALTERED_SEARCH_PATH)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to load %s: %w", path, err)
+	}
+	return &windows.DLL{Name: path, Handle: handle}, nil
+}
+
+// findLoaderPath returns the path of the WebView2Loader.dll that loadLoader would load, searching
+// the same locations without loading it: the path given to SetLoaderPath, the folder of the
+// executable, System32, then the loader given to SetLoaderBytes, which is extracted if needed.
+func findLoaderPath() (string, error) {
+	if override := getLoaderPath(); override != "" {
+		path, err := filepath.Abs(override)
+		if err != nil {
+			return "", err
+		}
+		if _, err := os.Stat(path); err != nil {
+			return "", fmt.Errorf("unable to find %s: %w", loaderName, err)
+		}
+		return path, nil
+	}
+	var folders []string
+	if executable, err := os.Executable(); err == nil {
+		folders = append(folders, filepath.Dir(executable))
+	}
+	if system, err := windows.GetSystemDirectory(); err == nil {
+		folders = append(folders, system)
+	}
+	for _, folder := range folders {
+		path := filepath.Join(folder, loaderName)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	if dll := getLoaderBytes(); dll != nil {
+		return extractLoader(dll)
+	}
+	return "", fmt.Errorf("unable to find %s: %w", loaderName, os.ErrNotExist)
+}
+
+// VerifyLoaderBitness checks that the WebView2Loader.dll this package would load has the same
+// architecture as the current process. A loader with the wrong bitness cannot be loaded and
+// causes cryptic failures when comparing versions or creating environments, so the loader is
+// found and its PE header read without loading it.
+// Returns false and an error describing the mismatch if the architectures differ.
+func VerifyLoaderBitness() (bool, error) {
+	path, err := findLoaderPath()
+	if err != nil {
+		return false, err
+	}
+	return verifyBitness(path, processArchitecture())
+}
+
+// systemLoader calls the functions exported by WebView2Loader.dll.
+type systemLoader struct{}
+
+func (systemLoader) AvailableBrowserVersion() (string, error) {
+	GetAvailableCoreWebView2BrowserVersionString, err := findLoaderProc("GetAvailableCoreWebView2BrowserVersionString")
+	if err != nil {
+		return "", err
+	}
+
+	var result *uint16
+	res, _, _ := GetAvailableCoreWebView2BrowserVersionString.Call(
+		uintptr(unsafe.Pointer(nil)),
+		uintptr(unsafe.Pointer(&result)),
+	)
+	if res != 0 {
+		return "", fmt.Errorf("GetAvailableCoreWebView2BrowserVersionString failed with HRESULT 0x%08x", res)
+	}
+	version := windows.UTF16PtrToString(result)
+	windows.CoTaskMemFree(unsafe.Pointer(result))
+	return version, nil
+}
+
+// CompareBrowserVersions compares the versions in pure Go. WebView2Loader.dll is only used, if available,
+// for versions that cannot be parsed as dotted numbers.
+func (systemLoader) CompareBrowserVersions(v1 string, v2 string) (int, error) {
+	result, err := compareVersions(stripChannel(v1), stripChannel(v2))
+	if err == nil {
+		return result, nil
+	}
+	CompareBrowserVersions, findErr := findLoaderProc("CompareBrowserVersions")
+	if findErr != nil {
+		return 0, err
+	}
+	v1UTF16, err := syscall.UTF16PtrFromString(v1)
+	if err != nil {
+		return 0, err
+	}
+	v2UTF16, err := syscall.UTF16PtrFromString(v2)
+	if err != nil {
+		return 0, err
+	}
+	// CompareBrowserVersions writes a 32-bit int
+	var dllResult int32 = 9
+	res, _, _ := CompareBrowserVersions.Call(uintptr(unsafe.Pointer(v1UTF16)), uintptr(unsafe.Pointer(v2UTF16)), uintptr(unsafe.Pointer(&dllResult)))
+	if res != 0 {
+		return 0, fmt.Errorf("CompareBrowserVersions(%q, %q) failed with HRESULT 0x%08x", v1, v2, res)
+	}
+	if dllResult < -1 || dllResult > 1 {
+		return 0, fmt.Errorf("CompareBrowserVersions(%q, %q) returned invalid result %d", v1, v2, dllResult)
+	}
+	return int(dllResult), nil
+}
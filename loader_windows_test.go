@@ -0,0 +1,94 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"debug/pe"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// wrongLoaderSample returns a PE sample whose architecture differs from the process.
+func wrongLoaderSample() (uint16, Arch) {
+	if processArchitecture() == ArchX64 {
+		return pe.IMAGE_FILE_MACHINE_ARM64, ArchARM64
+	}
+	return pe.IMAGE_FILE_MACHINE_AMD64, ArchX64
+}
+
+// processLoaderSample returns the PE machine type of the process architecture.
+func processLoaderSample() uint16 {
+	for _, sample := range peSamples {
+		if sample.arch == processArchitecture() {
+			return sample.machine
+		}
+	}
+	return 0
+}
+
+func TestVerifyLoaderBitness(t *testing.T) {
+	wrongMachine, wrongArch := wrongLoaderSample()
+	tests := []struct {
+		name    string
+		machine uint16
+		// bytes provides the loader using SetLoaderBytes rather than SetLoaderPath.
+		bytes bool
+		err   string
+	}{
+		{name: "matching loader path", machine: processLoaderSample()},
+		{name: "wrong loader path", machine: wrongMachine, err: "is " + wrongArch.String() + " but the process is"},
+		{name: "matching loader bytes", machine: processLoaderSample(), bytes: true},
+		{name: "wrong loader bytes", machine: wrongMachine, bytes: true, err: "is " + wrongArch.String() + " but the process is"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			setenv(t, "LOCALAPPDATA", t.TempDir())
+			t.Cleanup(func() {
+				SetLoaderPath("")
+				SetLoaderBytes(nil)
+			})
+			path := filepath.Join(t.TempDir(), loaderName)
+			writePE(t, path, test.machine)
+			if test.bytes {
+				dll, err := os.ReadFile(path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				SetLoaderBytes(dll)
+				found, err := findLoaderPath()
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !strings.HasPrefix(found, os.Getenv("LOCALAPPDATA")) {
+					t.Skipf("a loader at %s is found before the loader bytes", found)
+				}
+			} else {
+				SetLoaderPath(path)
+			}
+
+			ok, err := VerifyLoaderBitness()
+			if test.err == "" {
+				if !ok || err != nil {
+					t.Errorf("VerifyLoaderBitness() = %t, %v, want true", ok, err)
+				}
+				return
+			}
+			if ok || err == nil || !strings.Contains(err.Error(), test.err) {
+				t.Errorf("VerifyLoaderBitness() = %t, %v, want false and an error containing %q", ok, err, test.err)
+			}
+		})
+	}
+}
+
+func TestVerifyLoaderBitnessMissingPath(t *testing.T) {
+	SetLoaderPath(filepath.Join(t.TempDir(), loaderName))
+	defer SetLoaderPath("")
+	ok, err := VerifyLoaderBitness()
+	if ok || !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("VerifyLoaderBitness() = %t, %v, want false and a not exist error", ok, err)
+	}
+}
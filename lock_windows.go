@@ -0,0 +1,179 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+	"fmt"
+	"golang.org/x/sys/windows"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// installLock serialises installs within this process, in addition to the named mutex.
+var installLock = make(chan struct{}, 1)
+
+var (
+	pathLocksLock sync.Mutex
+	pathLocks     = map[string]*sync.Mutex{}
+)
+
+// lockPath stops other goroutines in this process using the given file until the returned
+// function is called. Paths are compared case insensitively, as they are on Windows.
+func lockPath(path string) func() {
+	key := strings.ToLower(filepath.Clean(path))
+	pathLocksLock.Lock()
+	lock, ok := pathLocks[key]
+	if !ok {
+		lock = &sync.Mutex{}
+		pathLocks[key] = lock
+	}
+	pathLocksLock.Unlock()
+	lock.Lock()
+	return lock.Unlock
+}
+
+// acquireInstallLockAndRecheck is the same as acquireInstallLock but, if it had to wait for another
+// install, checks whether that install changed the installed version. If it did, the install is
+// unnecessary and a successful result is returned with ReasonAlreadyInstalled.
+// The check is skipped if the ReinstallAfterWait option is set.
+func acquireInstallLockAndRecheck(ctx context.Context, options *InstallOptions) (func(), *InstallResult, error) {
+	// The cache is bypassed as another process may have changed the installation
+	before := defaultDetector.InstalledVersion()
+	release, waited, err := acquireInstallLock(ctx, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	if waited && !options.reinstallAfterWait() {
+		after := defaultDetector.InstalledVersion()
+		logEvent("waited for another install", "before", before, "after", after)
+		if after != "" && after != before {
+			return release, &InstallResult{Reason: ReasonAlreadyInstalled, Success: true}, nil
+		}
+	}
+	return release, nil, nil
+}
+
+// acquireInstallLock waits until no other install is running, giving up if the context is cancelled.
+// Installs in this process are serialised first, then the named mutex and, if set, the lock file
+// are acquired to serialise installs across processes. The returned function releases all of them.
+// Returns true if another install had to finish first.
+func acquireInstallLock(ctx context.Context, options *InstallOptions) (func(), bool, error) {
+	waited := false
+	select {
+	case installLock <- struct{}{}:
+	default:
+		waited = true
+		select {
+		case installLock <- struct{}{}:
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		}
+	}
+	unlock := func() { <-installLock }
+
+	releaseMutex, mutexWaited, err := acquireInstallMutex(ctx, options.mutexName())
+	if err != nil {
+		unlock()
+		return nil, false, err
+	}
+	waited = waited || mutexWaited
+	releaseFile := func() {}
+	if options.lockFile() != "" {
+		var fileWaited bool
+		releaseFile, fileWaited, err = acquireLockFile(ctx, options.lockFile())
+		if err != nil {
+			releaseMutex()
+			unlock()
+			return nil, false, err
+		}
+		waited = waited || fileWaited
+	}
+	return func() {
+		releaseFile()
+		releaseMutex()
+		unlock()
+	}, waited, nil
+}
+
+// acquireLockFile waits until the lock file can be opened exclusively, giving up if the context is cancelled.
+// The returned function closes the file, which deletes it. Returns true if it had to wait.
+func acquireLockFile(ctx context.Context, path string) (func(), bool, error) {
+	pathPtr, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return nil, false, err
+	}
+	for waited := false; ; waited = true {
+		handle, err := windows.CreateFile(pathPtr, windows.GENERIC_WRITE, 0, nil, windows.OPEN_ALWAYS,
+			windows.FILE_ATTRIBUTE_NORMAL|windows.FILE_FLAG_DELETE_ON_CLOSE, 0)
+		if err == nil {
+			return func() { windows.CloseHandle(handle) }, waited, nil
+		}
+		// A lock file that is being deleted by its previous owner gives access denied
+		if err != windows.ERROR_SHARING_VIOLATION && err != windows.ERROR_ACCESS_DENIED {
+			return nil, false, fmt.Errorf("unable to open lock file %s: %w", path, err)
+		}
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-time.After(processPollInterval):
+		}
+	}
+}
+
+// acquireInstallMutex waits for ownership of the named mutex, giving up if the context is cancelled.
+// The returned function releases the mutex. Returns true if another process held the mutex.
+//
+// Windows mutexes are owned by a thread, so the mutex is acquired and released
+// by a dedicated goroutine locked to its OS thread.
+func acquireInstallMutex(ctx context.Context, name string) (func(), bool, error) {
+	namePtr, err := windows.UTF16PtrFromString(name)
+	if err != nil {
+		return nil, false, err
+	}
+
+	waited := false
+	acquired := make(chan error)
+	done := make(chan struct{})
+	go func() {
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+
+		// CreateMutex returns ERROR_ALREADY_EXISTS alongside a valid handle if the mutex exists
+		handle, err := windows.CreateMutex(nil, false, namePtr)
+		if handle == 0 {
+			acquired <- fmt.Errorf("unable to create mutex %s: %w", name, err)
+			return
+		}
+		defer windows.CloseHandle(handle)
+
+		for {
+			event, err := windows.WaitForSingleObject(handle, uint32(processPollInterval/time.Millisecond))
+			if event == windows.WAIT_OBJECT_0 || event == windows.WAIT_ABANDONED {
+				break
+			}
+			if event != uint32(windows.WAIT_TIMEOUT) {
+				acquired <- fmt.Errorf("unable to acquire mutex %s: %w", name, err)
+				return
+			}
+			if ctx.Err() != nil {
+				acquired <- ctx.Err()
+				return
+			}
+			waited = true
+		}
+		acquired <- nil
+		<-done
+		windows.ReleaseMutex(handle)
+	}()
+
+	err = <-acquired
+	if err != nil {
+		return nil, false, err
+	}
+	return func() { close(done) }, waited, nil
+}
@@ -0,0 +1,122 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// testMutexName returns a mutex name that is unique to the test.
+func testMutexName() string {
+	return fmt.Sprintf(`Local\webview2runtime-test-%d-%d`, os.Getpid(), time.Now().UnixNano())
+}
+
+func TestInstallLockSerialises(t *testing.T) {
+	tests := []struct {
+		name    string
+		acquire func(ctx context.Context, options *InstallOptions) (func(), bool, error)
+	}{
+		{"named mutex", func(ctx context.Context, options *InstallOptions) (func(), bool, error) {
+			return acquireInstallMutex(ctx, options.mutexName())
+		}},
+		{"lock file", func(ctx context.Context, options *InstallOptions) (func(), bool, error) {
+			return acquireLockFile(ctx, options.lockFile())
+		}},
+		{"install lock", acquireInstallLock},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := &InstallOptions{
+				MutexName: testMutexName(),
+				LockFile:  filepath.Join(t.TempDir(), "install.lock"),
+			}
+			ctx := context.Background()
+			release, waited, err := test.acquire(ctx, options)
+			if err != nil {
+				t.Fatalf("first acquire error = %v", err)
+			}
+			if waited {
+				t.Errorf("first acquire waited = true, want false")
+			}
+
+			type acquired struct {
+				release func()
+				waited  bool
+				err     error
+			}
+			second := make(chan acquired, 1)
+			go func() {
+				release, waited, err := test.acquire(ctx, options)
+				second <- acquired{release, waited, err}
+			}()
+
+			select {
+			case result := <-second:
+				if result.release != nil {
+					result.release()
+				}
+				release()
+				t.Fatalf("second acquire returned while the lock was held, error = %v", result.err)
+			case <-time.After(3 * processPollInterval):
+			}
+
+			release()
+			select {
+			case result := <-second:
+				if result.err != nil {
+					t.Fatalf("second acquire error = %v", result.err)
+				}
+				result.release()
+				if !result.waited {
+					t.Errorf("second acquire waited = false, want true")
+				}
+			case <-time.After(30 * processPollInterval):
+				t.Fatal("second acquire did not return after the lock was released")
+			}
+		})
+	}
+}
+
+func TestInstallLockCancelled(t *testing.T) {
+	options := &InstallOptions{MutexName: testMutexName()}
+	release, _, err := acquireInstallLock(context.Background(), options)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*processPollInterval)
+	defer cancel()
+	_, _, err = acquireInstallLock(ctx, options)
+	if err != context.DeadlineExceeded {
+		t.Errorf("acquireInstallLock() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestLockPathIgnoresCase(t *testing.T) {
+	release := lockPath(`C:\Temp\Setup.exe`)
+	locked := make(chan struct{})
+	go func() {
+		unlock := lockPath(`c:\temp\setup.EXE`)
+		close(locked)
+		unlock()
+	}()
+
+	select {
+	case <-locked:
+		t.Fatal("lockPath() did not block for the same path in a different case")
+	case <-time.After(100 * time.Millisecond):
+	}
+	release()
+	select {
+	case <-locked:
+	case <-time.After(5 * time.Second):
+		t.Fatal("lockPath() did not return after the path was released")
+	}
+}
@@ -0,0 +1,68 @@
+package webview2runtime
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+)
+
+// Logger receives a record of each step this package takes, eg registry reads, download URLs,
+// HTTP status codes, file paths, installer arguments and exit codes.
+type Logger interface {
+	// Log records an event with alternating key and value pairs, eg
+	// Log("download finished", "url", url, "status", 200).
+	Log(message string, keyvals ...interface{})
+}
+
+// LoggerFunc adapts a function to a Logger.
+type LoggerFunc func(message string, keyvals ...interface{})
+
+// Log calls f.
+func (f LoggerFunc) Log(message string, keyvals ...interface{}) {
+	f(message, keyvals...)
+}
+
+// StdLogger returns a Logger that writes each event to the given log.Logger as a single line
+// of the form `message key=value key=value`.
+func StdLogger(logger *log.Logger) Logger {
+	return LoggerFunc(func(message string, keyvals ...interface{}) {
+		logger.Print(formatLog(message, keyvals))
+	})
+}
+
+// formatLog formats an event as `message key=value key=value`.
+func formatLog(message string, keyvals []interface{}) string {
+	var builder strings.Builder
+	builder.WriteString(message)
+	for i := 0; i < len(keyvals); i += 2 {
+		var value interface{} = "(missing)"
+		if i+1 < len(keyvals) {
+			value = keyvals[i+1]
+		}
+		fmt.Fprintf(&builder, " %v=%v", keyvals[i], value)
+	}
+	return builder.String()
+}
+
+var (
+	loggerLock sync.Mutex
+	logger     Logger
+)
+
+// SetLogger sets the Logger this package writes to. Passing nil stops logging, which is the default.
+func SetLogger(l Logger) {
+	loggerLock.Lock()
+	defer loggerLock.Unlock()
+	logger = l
+}
+
+// logEvent writes the event to the logger, if one is set.
+func logEvent(message string, keyvals ...interface{}) {
+	loggerLock.Lock()
+	l := logger
+	loggerLock.Unlock()
+	if l != nil {
+		l.Log(message, keyvals...)
+	}
+}
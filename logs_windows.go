@@ -0,0 +1,127 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// installerOutputLimit is how much of the end of the output of the installer is kept.
+const installerOutputLimit = 64 * 1024
+
+// installerLogTail is how much of the end of each log is attached to the result of a failed install
+// and included in a diagnostics report.
+const installerLogTail = 16 * 1024
+
+// InstallerLogPaths returns the paths of the installer and EdgeUpdate logs that exist on this machine:
+// the per-machine and per-user EdgeUpdate logs and the msedge_installer logs in the temp directories.
+func InstallerLogPaths() []string {
+	var candidates []string
+	for _, env := range []string{"ProgramData", "LOCALAPPDATA"} {
+		base := os.Getenv(env)
+		if base == "" {
+			continue
+		}
+		matches, _ := filepath.Glob(filepath.Join(base, "Microsoft", "EdgeUpdate", "Log", "*.log"))
+		candidates = append(candidates, matches...)
+	}
+	candidates = append(candidates, installerTempLogPaths()...)
+
+	var paths []string
+	seen := map[string]bool{}
+	for _, path := range candidates {
+		key := filepath.Clean(path)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// installerTempLogPaths returns the paths of the msedge_installer logs the installer writes when
+// asked to log. Per-machine installs run as SYSTEM, which logs to the Windows temp directory.
+func installerTempLogPaths() []string {
+	dirs := []string{os.TempDir()}
+	if windowsDir := os.Getenv("SystemRoot"); windowsDir != "" {
+		dirs = append(dirs, filepath.Join(windowsDir, "Temp"))
+	}
+	paths := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		paths = append(paths, filepath.Join(dir, "msedge_installer.log"))
+	}
+	return paths
+}
+
+// runLogPaths returns the msedge_installer logs written since the given time when the LogLevel
+// switches asked the installer to log. The EdgeUpdate logs are shared with every other install,
+// so they are never included.
+func (o *InstallOptions) runLogPaths(since time.Time) []string {
+	if o == nil || o.LogLevel == LogLevelNone {
+		return nil
+	}
+	var paths []string
+	for _, path := range installerTempLogPaths() {
+		if info, err := os.Stat(path); err == nil && !info.IsDir() && !info.ModTime().Before(since) {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+// TailInstallerLogs returns up to the last maxBytes of each of the InstallerLogPaths, keyed by path.
+// Logs that cannot be read are skipped; the error is the first problem found, if any.
+func TailInstallerLogs(maxBytes int64) (map[string]string, error) {
+	logs := map[string]string{}
+	var firstErr error
+	for _, path := range InstallerLogPaths() {
+		tail, err := tailFile(path, maxBytes)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		logs[path] = tail
+	}
+	return logs, firstErr
+}
+
+// tailBuffer is an io.Writer that keeps only the last limit bytes written to it.
+type tailBuffer struct {
+	lock   sync.Mutex
+	limit  int
+	buffer bytes.Buffer
+}
+
+func (b *tailBuffer) Write(data []byte) (int, error) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.buffer.Write(data)
+	if extra := b.buffer.Len() - b.limit; extra > 0 {
+		b.buffer.Next(extra)
+	}
+	return len(data), nil
+}
+
+// Len returns the number of bytes kept.
+func (b *tailBuffer) Len() int {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buffer.Len()
+}
+
+// String returns the bytes kept.
+func (b *tailBuffer) String() string {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return b.buffer.String()
+}
@@ -0,0 +1,118 @@
+package webview2runtime
+
+import (
+	"context"
+	"sync"
+)
+
+// RuntimeManager detects, installs and compares versions of the runtime and prompts the user.
+// Apps that depend on RuntimeManager rather than the package level functions can test their install
+// flows with a FakeRuntimeManager, without Windows or network access.
+type RuntimeManager interface {
+	// Detect returns the installed runtime, or nil if it is not installed.
+	Detect() (*Info, error)
+	// Install installs the runtime using the given options, which may be nil. The result is never nil.
+	Install(ctx context.Context, options *InstallOptions) (*InstallResult, error)
+	// Compare returns -1, 0 or 1 if v1 is older than, the same as or newer than v2.
+	Compare(v1 string, v2 string) (int, error)
+	// Prompt asks the user to confirm and returns true if they agree.
+	Prompt(caption string, title string) (bool, error)
+}
+
+// NewRuntimeManager returns a RuntimeManager that uses the package level functions: Detect,
+// InstallUsingBootstrapperWithResult, CompareVersions and Confirm.
+func NewRuntimeManager() RuntimeManager {
+	return systemRuntimeManager{}
+}
+
+type systemRuntimeManager struct{}
+
+func (systemRuntimeManager) Detect() (*Info, error) {
+	return Detect()
+}
+
+func (systemRuntimeManager) Install(ctx context.Context, options *InstallOptions) (*InstallResult, error) {
+	return InstallUsingBootstrapperWithResult(ctx, options)
+}
+
+func (systemRuntimeManager) Compare(v1 string, v2 string) (int, error) {
+	return CompareVersions(v1, v2), nil
+}
+
+func (systemRuntimeManager) Prompt(caption string, title string) (bool, error) {
+	return Confirm(caption, title)
+}
+
+// FakeRuntimeManager is a RuntimeManager that returns canned results and records how it was called.
+// It is intended for tests. The zero value has no runtime installed, successfully "installs" nothing
+// and declines prompts. It is safe for concurrent use.
+type FakeRuntimeManager struct {
+	// Installed is the installation returned by Detect.
+	Installed *Info
+	// DetectErr is returned by Detect.
+	DetectErr error
+
+	// InstalledAfterInstall, if set, replaces Installed when Install succeeds.
+	InstalledAfterInstall *Info
+	// InstallResult is returned by Install. If nil, a successful result is returned.
+	InstallResult *InstallResult
+	// InstallErr is returned by Install. If set, Installed is not changed.
+	InstallErr error
+
+	// CompareErr is returned by Compare. Versions are compared using CompareVersions.
+	CompareErr error
+
+	// PromptResult and PromptErr are returned by Prompt.
+	PromptResult bool
+	PromptErr    error
+
+	// Installs is the number of times Install has been called.
+	Installs int
+	// Prompts are the captions passed to Prompt.
+	Prompts []string
+
+	lock sync.Mutex
+}
+
+// Detect returns Installed and DetectErr.
+func (f *FakeRuntimeManager) Detect() (*Info, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.Installed, f.DetectErr
+}
+
+// Install records the call and returns InstallResult and InstallErr.
+func (f *FakeRuntimeManager) Install(ctx context.Context, options *InstallOptions) (*InstallResult, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.Installs++
+	result := f.InstallResult
+	if result == nil {
+		result = &InstallResult{Success: true, Reason: ReasonSuccess}
+		if f.InstallErr != nil {
+			result = &InstallResult{Reason: ReasonFailed, Error: f.InstallErr}
+		}
+	}
+	if f.InstallErr == nil && f.InstalledAfterInstall != nil {
+		f.Installed = f.InstalledAfterInstall
+	}
+	return result, f.InstallErr
+}
+
+// Compare compares the versions using CompareVersions, returning CompareErr if it is set.
+func (f *FakeRuntimeManager) Compare(v1 string, v2 string) (int, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if f.CompareErr != nil {
+		return 0, f.CompareErr
+	}
+	return CompareVersions(v1, v2), nil
+}
+
+// Prompt records the caption and returns PromptResult and PromptErr.
+func (f *FakeRuntimeManager) Prompt(caption string, title string) (bool, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.Prompts = append(f.Prompts, caption)
+	return f.PromptResult, f.PromptErr
+}
@@ -0,0 +1,119 @@
+package webview2runtime
+
+import (
+	"strings"
+	"sync"
+)
+
+// Messages are the user facing strings shown by EnsureInstalled.
+type Messages struct {
+	// Title is the title of the prompts.
+	Title string
+	// MissingRuntime asks the user to install the runtime when it is not installed.
+	MissingRuntime string
+	// OutdatedRuntime asks the user to install the runtime when the installed version is too old.
+	OutdatedRuntime string
+	// DownloadFailed tells the user the runtime could not be downloaded.
+	DownloadFailed string
+	// Downloading is shown in the progress dialog while the installer downloads.
+	Downloading string
+	// Installing is shown in the progress dialog while the installer runs.
+	Installing string
+	// RuntimeInUse asks the user to close the apps using the runtime before it is repaired or uninstalled.
+	RuntimeInUse string
+}
+
+// withDefaults returns the messages with any blank strings taken from the given defaults.
+func (m Messages) withDefaults(defaults Messages) Messages {
+	if m.Title == "" {
+		m.Title = defaults.Title
+	}
+	if m.MissingRuntime == "" {
+		m.MissingRuntime = defaults.MissingRuntime
+	}
+	if m.OutdatedRuntime == "" {
+		m.OutdatedRuntime = defaults.OutdatedRuntime
+	}
+	if m.DownloadFailed == "" {
+		m.DownloadFailed = defaults.DownloadFailed
+	}
+	if m.Downloading == "" {
+		m.Downloading = defaults.Downloading
+	}
+	if m.Installing == "" {
+		m.Installing = defaults.Installing
+	}
+	if m.RuntimeInUse == "" {
+		m.RuntimeInUse = defaults.RuntimeInUse
+	}
+	return m
+}
+
+// defaultLanguage is used when there are no messages for the user's language.
+const defaultLanguage = "en"
+
+var (
+	messagesLock sync.Mutex
+	messages     = map[string]Messages{
+		"en": {
+			Title:           "Missing Requirements",
+			MissingRuntime:  "The WebView2 runtime is required. Press Ok to install.",
+			OutdatedRuntime: "The WebView2 runtime needs updating. Press Ok to install.",
+			DownloadFailed:  "The WebView2 runtime could not be downloaded. Please check your internet connection and try again.",
+			Downloading:     "Downloading the WebView2 runtime...",
+			Installing:      "Installing the WebView2 runtime...",
+			RuntimeInUse:    "The following applications are using the WebView2 runtime. Please close them and press Retry.",
+		},
+		"de": {
+			Title:           "Fehlende Voraussetzungen",
+			MissingRuntime:  "Die WebView2-Laufzeit wird benötigt. Klicken Sie auf OK, um sie zu installieren.",
+			OutdatedRuntime: "Die WebView2-Laufzeit muss aktualisiert werden. Klicken Sie auf OK, um sie zu installieren.",
+			DownloadFailed:  "Die WebView2-Laufzeit konnte nicht heruntergeladen werden. Bitte überprüfen Sie Ihre Internetverbindung und versuchen Sie es erneut.",
+			Downloading:     "Die WebView2-Laufzeit wird heruntergeladen...",
+			Installing:      "Die WebView2-Laufzeit wird installiert...",
+			RuntimeInUse:    "Die folgenden Anwendungen verwenden die WebView2-Laufzeit. Bitte schließen Sie sie und klicken Sie auf Wiederholen.",
+		},
+		"es": {
+			Title:           "Faltan requisitos",
+			MissingRuntime:  "Se requiere el runtime de WebView2. Pulse Aceptar para instalarlo.",
+			OutdatedRuntime: "Es necesario actualizar el runtime de WebView2. Pulse Aceptar para instalarlo.",
+			DownloadFailed:  "No se pudo descargar el runtime de WebView2. Compruebe su conexión a Internet e inténtelo de nuevo.",
+			Downloading:     "Descargando el runtime de WebView2...",
+			Installing:      "Instalando el runtime de WebView2...",
+			RuntimeInUse:    "Las siguientes aplicaciones están usando el runtime de WebView2. Ciérrelas y pulse Reintentar.",
+		},
+		"fr": {
+			Title:           "Configuration requise manquante",
+			MissingRuntime:  "Le runtime WebView2 est requis. Cliquez sur OK pour l'installer.",
+			OutdatedRuntime: "Le runtime WebView2 doit être mis à jour. Cliquez sur OK pour l'installer.",
+			DownloadFailed:  "Impossible de télécharger le runtime WebView2. Vérifiez votre connexion Internet et réessayez.",
+			Downloading:     "Téléchargement du runtime WebView2...",
+			Installing:      "Installation du runtime WebView2...",
+			RuntimeInUse:    "Les applications suivantes utilisent le runtime WebView2. Fermez-les puis cliquez sur Réessayer.",
+		},
+	}
+)
+
+// SetMessages sets the messages used for the given language code, eg "de".
+// Blank strings are taken from the English messages.
+func SetMessages(language string, languageMessages Messages) {
+	messagesLock.Lock()
+	defer messagesLock.Unlock()
+	messages[strings.ToLower(language)] = languageMessages.withDefaults(messages[defaultLanguage])
+}
+
+// MessagesFor returns the messages for the given language code, falling back to English.
+func MessagesFor(language string) Messages {
+	messagesLock.Lock()
+	defer messagesLock.Unlock()
+	result, ok := messages[strings.ToLower(language)]
+	if !ok {
+		return messages[defaultLanguage]
+	}
+	return result
+}
+
+// DefaultMessages returns the messages for the user's UI language, falling back to English.
+func DefaultMessages() Messages {
+	return MessagesFor(UserLanguage())
+}
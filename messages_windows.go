@@ -0,0 +1,28 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+var procGetUserDefaultUILanguage = modkernel32.NewProc("GetUserDefaultUILanguage")
+
+// languages maps Windows primary language IDs to language codes.
+var languages = map[uint16]string{
+	0x04: "zh", // LANG_CHINESE
+	0x07: "de", // LANG_GERMAN
+	0x09: "en", // LANG_ENGLISH
+	0x0a: "es", // LANG_SPANISH
+	0x0c: "fr", // LANG_FRENCH
+	0x10: "it", // LANG_ITALIAN
+	0x11: "ja", // LANG_JAPANESE
+	0x13: "nl", // LANG_DUTCH
+	0x15: "pl", // LANG_POLISH
+	0x16: "pt", // LANG_PORTUGUESE
+	0x19: "ru", // LANG_RUSSIAN
+}
+
+// UserLanguage returns the language code of the user's UI language, eg "de".
+// Returns a blank string if the language is not recognised.
+func UserLanguage() string {
+	langID, _, _ := procGetUserDefaultUILanguage.Call()
+	return languages[uint16(langID)&0x3ff]
+}
@@ -0,0 +1,195 @@
+package webview2runtime
+
+import (
+	"expvar"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Metrics is a Telemetry that keeps counters and gauges for long running agents, eg a kiosk fleet
+// manager. Pass it to SetTelemetry, then expose it with Publish for expvar, WritePrometheus for the
+// Prometheus text format, or Samples for an adapter such as a prometheus.Collector.
+// The zero value is ready to use.
+type Metrics struct {
+	lock sync.Mutex
+
+	installsAttempted uint64
+	installsSucceeded uint64
+	installFailures   map[Reason]uint64
+	downloads         uint64
+	downloadFailures  uint64
+	downloadedBytes   uint64
+	detections        uint64
+	runtimeVersion    string
+	lastCheck         time.Time
+	lastInstall       time.Time
+}
+
+// NewMetrics returns an empty Metrics.
+func NewMetrics() *Metrics {
+	return &Metrics{}
+}
+
+// DetectionResult records the detected version and the time of the check.
+func (m *Metrics) DetectionResult(event DetectionTelemetry) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.detections++
+	m.lastCheck = time.Now()
+	if event.Err == nil {
+		m.runtimeVersion = event.Version
+	}
+}
+
+// Download records the download.
+func (m *Metrics) Download(event DownloadTelemetry) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.downloads++
+	if event.Err != nil {
+		m.downloadFailures++
+	}
+	m.downloadedBytes += uint64(event.Bytes)
+}
+
+// InstallResult records the install.
+func (m *Metrics) InstallResult(event InstallTelemetry) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.installsAttempted++
+	m.lastInstall = time.Now()
+	if event.Success {
+		m.installsSucceeded++
+		return
+	}
+	if m.installFailures == nil {
+		m.installFailures = map[Reason]uint64{}
+	}
+	m.installFailures[event.Reason]++
+}
+
+// MetricType is the kind of a MetricSample.
+type MetricType string
+
+const (
+	// MetricCounter is a value that only increases.
+	MetricCounter MetricType = "counter"
+	// MetricGauge is a value that can go up and down.
+	MetricGauge MetricType = "gauge"
+)
+
+// MetricSample is the current value of a metric.
+type MetricSample struct {
+	Name   string
+	Help   string
+	Type   MetricType
+	Value  float64
+	Labels map[string]string
+}
+
+// Samples returns the current value of every metric, in a stable order.
+// Timestamps are given as Unix seconds, and are 0 if the event has not happened.
+func (m *Metrics) Samples() []MetricSample {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	samples := []MetricSample{
+		{Name: "webview2runtime_installs_attempted_total", Help: "Installs of the runtime that were attempted.", Type: MetricCounter, Value: float64(m.installsAttempted)},
+		{Name: "webview2runtime_installs_succeeded_total", Help: "Installs of the runtime that succeeded.", Type: MetricCounter, Value: float64(m.installsSucceeded)},
+	}
+	reasons := make([]Reason, 0, len(m.installFailures))
+	for reason := range m.installFailures {
+		reasons = append(reasons, reason)
+	}
+	sort.Slice(reasons, func(i, j int) bool { return reasons[i] < reasons[j] })
+	for _, reason := range reasons {
+		samples = append(samples, MetricSample{
+			Name:   "webview2runtime_install_failures_total",
+			Help:   "Installs of the runtime that failed, by reason.",
+			Type:   MetricCounter,
+			Value:  float64(m.installFailures[reason]),
+			Labels: map[string]string{"reason": reason.String()},
+		})
+	}
+	runtimeInstalled := 0.0
+	if m.runtimeVersion != "" {
+		runtimeInstalled = 1
+	}
+	samples = append(samples,
+		MetricSample{Name: "webview2runtime_downloads_total", Help: "Downloads of the bootstrapper that were attempted.", Type: MetricCounter, Value: float64(m.downloads)},
+		MetricSample{Name: "webview2runtime_download_failures_total", Help: "Downloads of the bootstrapper that failed.", Type: MetricCounter, Value: float64(m.downloadFailures)},
+		MetricSample{Name: "webview2runtime_downloaded_bytes_total", Help: "Bytes of bootstrapper downloaded.", Type: MetricCounter, Value: float64(m.downloadedBytes)},
+		MetricSample{Name: "webview2runtime_detections_total", Help: "Detections of the installed runtime.", Type: MetricCounter, Value: float64(m.detections)},
+		MetricSample{Name: "webview2runtime_info", Help: "Whether the runtime is installed, labelled with the installed version.", Type: MetricGauge, Value: runtimeInstalled, Labels: map[string]string{"version": m.runtimeVersion}},
+		MetricSample{Name: "webview2runtime_last_check_timestamp_seconds", Help: "When the installed runtime was last detected.", Type: MetricGauge, Value: unixSeconds(m.lastCheck)},
+		MetricSample{Name: "webview2runtime_last_install_timestamp_seconds", Help: "When the last install finished.", Type: MetricGauge, Value: unixSeconds(m.lastInstall)},
+	)
+	return samples
+}
+
+func unixSeconds(t time.Time) float64 {
+	if t.IsZero() {
+		return 0
+	}
+	return float64(t.UnixNano()) / float64(time.Second)
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format.
+func (m *Metrics) WritePrometheus(out io.Writer) error {
+	w := &metricsWriter{w: out}
+	var last string
+	for _, sample := range m.Samples() {
+		if sample.Name != last {
+			w.printf("# HELP %s %s\n# TYPE %s %s\n", sample.Name, sample.Help, sample.Name, sample.Type)
+			last = sample.Name
+		}
+		w.printf("%s%s %v\n", sample.Name, formatLabels(sample.Labels), sample.Value)
+	}
+	return w.err
+}
+
+// metricsWriter writes formatted text, remembering the first error.
+type metricsWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (w *metricsWriter) printf(format string, args ...interface{}) {
+	if w.err == nil {
+		_, w.err = fmt.Fprintf(w.w, format, args...)
+	}
+}
+
+// formatLabels formats labels as `{name="value",...}`, sorted by name.
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, len(names))
+	for i, name := range names {
+		value := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(labels[name])
+		pairs[i] = fmt.Sprintf(`%s="%s"`, name, value)
+	}
+	return "{" + strings.Join(pairs, ",") + "}"
+}
+
+// Publish exposes the metrics through expvar under the given name, as a map of metric name to value.
+// Labelled metrics are keyed as in the Prometheus format, eg `webview2runtime_info{version="..."}`.
+// Like expvar.Publish, it panics if the name is already in use.
+func (m *Metrics) Publish(name string) {
+	expvar.Publish(name, expvar.Func(func() interface{} {
+		values := map[string]float64{}
+		for _, sample := range m.Samples() {
+			values[sample.Name+formatLabels(sample.Labels)] = sample.Value
+		}
+		return values
+	}))
+}
@@ -0,0 +1,185 @@
+package webview2runtime
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCleanup(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   *InstallOptions
+		succeeded bool
+		removed   bool
+	}{
+		{"nil options after success", nil, true, true},
+		{"nil options after failure", nil, false, true},
+		{"always after success", &InstallOptions{CleanupPolicy: CleanupAlways}, true, true},
+		{"always after failure", &InstallOptions{CleanupPolicy: CleanupAlways}, false, true},
+		{"on success after success", &InstallOptions{CleanupPolicy: CleanupOnSuccess}, true, true},
+		{"on success after failure", &InstallOptions{CleanupPolicy: CleanupOnSuccess}, false, false},
+		{"never after success", &InstallOptions{CleanupPolicy: CleanupNever}, true, false},
+		{"never after failure", &InstallOptions{CleanupPolicy: CleanupNever}, false, false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dir := t.TempDir()
+			installer := filepath.Join(dir, "MicrosoftEdgeWebview2Setup.exe")
+			log := filepath.Join(dir, "install.log")
+			for _, file := range []string{installer, log} {
+				err := os.WriteFile(file, []byte("test"), 0o600)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := test.options.cleanup(test.succeeded, installer, log)
+			if err != nil {
+				t.Fatalf("cleanup() error = %v", err)
+			}
+			for _, file := range []string{installer, log} {
+				_, err := os.Stat(file)
+				if removed := os.IsNotExist(err); removed != test.removed {
+					t.Errorf("%s removed = %t, want %t", filepath.Base(file), removed, test.removed)
+				}
+			}
+		})
+	}
+}
+
+func TestCleanupMissingFile(t *testing.T) {
+	missing := filepath.Join(t.TempDir(), "missing.exe")
+	err := (&InstallOptions{}).cleanup(true, missing)
+	if err != nil {
+		t.Errorf("cleanup() error = %v, want nil for a missing file", err)
+	}
+}
+
+// finishingTasks returns a pending task source that reports a running EdgeUpdate task the given
+// number of times, then calls finish and reports none.
+func finishingTasks(pending int, finish func()) func() ([]ScheduledTask, error) {
+	return func() ([]ScheduledTask, error) {
+		if pending > 0 {
+			pending--
+			return []ScheduledTask{{Name: "MicrosoftEdgeUpdateTaskMachineUA", Status: "Running"}}, nil
+		}
+		if finish != nil {
+			finish()
+			finish = nil
+		}
+		return nil, nil
+	}
+}
+
+func TestInstallOptionsVerifyPendingTasks(t *testing.T) {
+	tests := []struct {
+		name string
+		// registered is the version registered before the tasks finish, if any.
+		registered string
+		// finished is the version registered once the tasks finish, if any.
+		finished string
+		pending  int
+		expected string
+		wantErr  error
+	}{
+		{name: "registered by a pending task", finished: "120.0.2210.91", pending: 1},
+		{name: "not registered and no pending tasks", wantErr: ErrNotInstalled},
+		{name: "pending task does not register it", pending: 1, wantErr: ErrNotInstalled},
+		{name: "updated by a pending task", registered: "119.0.2151.97", finished: "120.0.2210.91", pending: 1, expected: "120.0.2210.91"},
+		{name: "wrong version and no pending tasks", registered: "119.0.2151.97", expected: "120.0.2210.91", wantErr: errors.New("expected version")},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			registry := RegistrySnapshot{}
+			if test.registered != "" {
+				registry = runtimeRegistry(test.registered)
+			}
+			options := &InstallOptions{
+				VerifyTimeout:   time.Millisecond,
+				ExpectedVersion: test.expected,
+				detector:        &Detector{Registry: registry, Loader: noLoader, Architecture: ArchX64},
+				pendingTasks: finishingTasks(test.pending, func() {
+					if test.finished != "" {
+						registry[machineRuntimeKey] = runtimeRegistry(test.finished)[machineRuntimeKey]
+					}
+				}),
+			}
+
+			err := options.verify(context.Background())
+			switch {
+			case test.wantErr == nil:
+				if err != nil {
+					t.Errorf("verify() error = %v", err)
+				}
+			case errors.Is(test.wantErr, ErrNotInstalled):
+				if !errors.Is(err, ErrNotInstalled) {
+					t.Errorf("verify() error = %v, want ErrNotInstalled", err)
+				}
+			default:
+				if err == nil || !strings.Contains(err.Error(), test.wantErr.Error()) {
+					t.Errorf("verify() error = %v, want %q", err, test.wantErr)
+				}
+			}
+		})
+	}
+}
+
+func TestInstallOptionsVerifyPendingTaskCancelled(t *testing.T) {
+	options := &InstallOptions{
+		VerifyTimeout: time.Millisecond,
+		detector:      &Detector{Registry: RegistrySnapshot{}, Loader: noLoader, Architecture: ArchX64},
+		pendingTasks:  finishingTasks(1000, nil),
+	}
+	// The context is done while waiting for the task, after the runtime was not detected
+	ctx, cancel := context.WithTimeout(context.Background(), verifyInitialDelay+pendingTaskPollInterval/2)
+	defer cancel()
+	err := options.verify(ctx)
+	if !errors.Is(err, ErrNotInstalled) {
+		t.Errorf("verify() error = %v, want ErrNotInstalled", err)
+	}
+}
+
+func TestInstallOptionsVerifyTargetChannel(t *testing.T) {
+	const canaryGUID = `{65C35B14-6C1D-4122-AC46-7148CC9D6497}`
+	stable := runtimeRegistry("120.0.2210.91")
+	beta := RegistrySnapshot{machineWOW64ClientsKey + betaGUID: {"pv": "121.0.2277.4"}}
+	both := runtimeRegistry("120.0.2210.91")
+	both[machineWOW64ClientsKey+betaGUID] = map[string]string{"pv": "121.0.2277.4"}
+	canary := RegistrySnapshot{currentUserClientsKey + canaryGUID: {"pv": "123.0.2400.0"}}
+
+	tests := []struct {
+		name     string
+		registry RegistrySnapshot
+		channel  Channel
+		expected string
+		wantErr  bool
+	}{
+		{name: "stable installed", registry: stable, channel: ChannelStable},
+		{name: "beta installed without stable", registry: beta, channel: ChannelBeta},
+		{name: "beta requested but only stable installed", registry: stable, channel: ChannelBeta, wantErr: true},
+		{name: "stable requested but only beta installed", registry: beta, channel: ChannelStable, wantErr: true},
+		{name: "beta version checked rather than stable", registry: both, channel: ChannelBeta, expected: "121.0.2277.4"},
+		{name: "stable version checked rather than beta", registry: both, channel: ChannelStable, expected: "121.0.2277.4", wantErr: true},
+		{name: "canary installed per-user", registry: canary, channel: ChannelCanary, expected: "123.0.2400.0"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			options := &InstallOptions{
+				TargetChannel:   test.channel,
+				VerifyTimeout:   time.Millisecond,
+				ExpectedVersion: test.expected,
+				detector:        &Detector{Registry: test.registry, Loader: noLoader, Architecture: ArchX64},
+				pendingTasks:    finishingTasks(0, nil),
+			}
+			err := options.verify(context.Background())
+			if (err != nil) != test.wantErr {
+				t.Errorf("verify() error = %v, want error %t", err, test.wantErr)
+			}
+		})
+	}
+}
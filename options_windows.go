@@ -0,0 +1,456 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// logLevelSwitches maps each LogLevel to the installer switches it adds:
+//
+//	LogLevelNone    -> (none)
+//	LogLevelNormal  -> /log
+//	LogLevelVerbose -> /log /verbose
+var logLevelSwitches = map[LogLevel][]string{
+	LogLevelNone:    nil,
+	LogLevelNormal:  {"/log"},
+	LogLevelVerbose: {"/log", "/verbose"},
+}
+
+// silentSwitches make the installer install without showing any UI.
+var silentSwitches = []string{"/silent", "/install"}
+
+const (
+	defaultInstallerFilename = `MicrosoftEdgeWebview2Setup.exe`
+	installerFilenamePattern = `MicrosoftEdgeWebview2Setup-*.exe`
+	defaultMutexName         = `Global\webview2runtime-install`
+)
+
+// installerPath returns the path to write the installer to in the given directory.
+// Unless InstallerFilename is set, an empty file with a unique name is created to reserve the path.
+// As every caller shares an InstallerFilename, its path is locked until the returned function is called.
+func (o *InstallOptions) installerPath(dir string) (string, func(), error) {
+	if o != nil && o.InstallerFilename != "" {
+		path := filepath.Join(dir, o.InstallerFilename)
+		return path, lockPath(path), nil
+	}
+	file, err := os.CreateTemp(dir, installerFilenamePattern)
+	if err != nil {
+		return "", nil, err
+	}
+	return file.Name(), func() {}, file.Close()
+}
+
+// httpClient returns the HTTP client to use for downloads.
+func (o *InstallOptions) httpClient() (*http.Client, error) {
+	if o == nil {
+		return getHTTPClient(), nil
+	}
+	if o.HTTPClient != nil {
+		return o.HTTPClient, nil
+	}
+	if o.Proxy == "" && o.SOCKS5Proxy == "" && o.TLSConfig == nil {
+		return getHTTPClient(), nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	if o.TLSConfig != nil {
+		transport.TLSClientConfig = o.TLSConfig.Clone()
+	}
+	if o.Proxy != "" {
+		proxy, err := parseProxy(o.Proxy)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = http.ProxyURL(proxy)
+	}
+	if o.SOCKS5Proxy != "" {
+		dialer, err := newSOCKS5Dialer(o.SOCKS5Proxy)
+		if err != nil {
+			return nil, err
+		}
+		transport.Proxy = nil
+		transport.DialContext = dialer.DialContext
+	}
+	return &http.Client{Transport: transport}, nil
+}
+
+func (o *InstallOptions) downloader() (Downloader, error) {
+	if o != nil && o.Downloader != nil {
+		return o.Downloader, nil
+	}
+	client, err := o.httpClient()
+	if err != nil {
+		return nil, err
+	}
+	return httpDownloader{client: client, options: o}, nil
+}
+
+func (o *InstallOptions) processRunner() ProcessRunner {
+	if o != nil && o.Runner != nil {
+		return o.Runner
+	}
+	return systemProcessRunner{options: o}
+}
+
+func (o *InstallOptions) bootstrapperURLs() []string {
+	if o == nil || len(o.BootstrapperURLs) == 0 {
+		return []string{bootstrapperURL}
+	}
+	return o.BootstrapperURLs
+}
+
+func (o *InstallOptions) downloadTimeout() time.Duration {
+	if o == nil {
+		return 0
+	}
+	return o.DownloadTimeout
+}
+
+func (o *InstallOptions) cachePolicy() *CachePolicy {
+	if o == nil {
+		return nil
+	}
+	return o.Cache
+}
+
+func (o *InstallOptions) retryPolicy() *RetryPolicy {
+	if o == nil {
+		return nil
+	}
+	return o.Retry
+}
+
+// parseProxy parses the URL of an HTTP proxy.
+func parseProxy(proxy string) (*url.URL, error) {
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid proxy: %w", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid proxy scheme: %s", u.Scheme)
+	}
+	return u, nil
+}
+
+// reportPhase calls PhaseChanged if it is set.
+func (o *InstallOptions) reportPhase(phase Phase) {
+	if o != nil && o.PhaseChanged != nil {
+		o.PhaseChanged(phase)
+	}
+}
+
+// reportEvent calls installEvent if it is set.
+func (o *InstallOptions) reportEvent(event InstallEvent) {
+	if o != nil && o.installEvent != nil {
+		o.installEvent(event)
+	}
+}
+
+func (o *InstallOptions) reportSpawned(processes []SpawnedProcess) {
+	if o != nil && o.spawned != nil {
+		o.spawned(processes)
+	}
+}
+
+func (o *InstallOptions) reportOutput(output string) {
+	if o != nil && o.output != nil {
+		o.output(output)
+	}
+}
+
+// collectInto returns a copy of the options that records the spawned processes and the output
+// of the programs that are run in the given result.
+func (o *InstallOptions) collectInto(result *InstallResult) *InstallOptions {
+	var collecting InstallOptions
+	if o != nil {
+		collecting = *o
+	}
+	collecting.spawned = func(spawned []SpawnedProcess) {
+		result.SpawnedProcesses = append(result.SpawnedProcesses, spawned...)
+	}
+	collecting.output = func(output string) {
+		result.Output += output
+	}
+	return &collecting
+}
+
+func (o *InstallOptions) onComplete() func(result *InstallResult) error {
+	if o == nil {
+		return nil
+	}
+	return o.OnComplete
+}
+
+func (o *InstallOptions) elevation() ElevationMode {
+	if o == nil {
+		return ElevationAuto
+	}
+	if o.Scope == InstallScopeUser {
+		return ElevationNever
+	}
+	return o.Elevation
+}
+
+func (o *InstallOptions) scope() InstallScope {
+	if o == nil {
+		return InstallScopeAuto
+	}
+	return o.Scope
+}
+
+// perUser returns a copy of the options that runs the installer without elevation, so it installs per-user.
+func (o *InstallOptions) perUser() *InstallOptions {
+	var result InstallOptions
+	if o != nil {
+		result = *o
+	}
+	result.Scope = InstallScopeUser
+	return &result
+}
+
+func (o *InstallOptions) installTimeout() time.Duration {
+	if o == nil {
+		return 0
+	}
+	return o.InstallTimeout
+}
+
+func (o *InstallOptions) dryRun() bool {
+	return o != nil && o.DryRun
+}
+
+func (o *InstallOptions) hideWindow() bool {
+	return o != nil && (o.HideWindow || o.Silent)
+}
+
+func (o *InstallOptions) commandHook() func(cmd *exec.Cmd) error {
+	if o == nil {
+		return nil
+	}
+	return o.CommandHook
+}
+
+func (o *InstallOptions) mutexName() string {
+	if o == nil || o.MutexName == "" {
+		return defaultMutexName
+	}
+	return o.MutexName
+}
+
+func (o *InstallOptions) reinstallAfterWait() bool {
+	return o != nil && o.ReinstallAfterWait
+}
+
+func (o *InstallOptions) lockFile() string {
+	if o == nil {
+		return ""
+	}
+	return o.LockFile
+}
+
+// withSilent returns a copy of the options with Silent set.
+func (o *InstallOptions) withSilent() *InstallOptions {
+	var result InstallOptions
+	if o != nil {
+		result = *o
+	}
+	result.Silent = true
+	return &result
+}
+
+// validate returns an error if any of the options are invalid.
+func (o *InstallOptions) validate() error {
+	if o == nil {
+		return nil
+	}
+	if _, ok := logLevelSwitches[o.LogLevel]; !ok {
+		return fmt.Errorf("invalid log level: %d", int(o.LogLevel))
+	}
+	if o.InstallerFilename != "" && filepath.Base(o.InstallerFilename) != o.InstallerFilename {
+		return fmt.Errorf("invalid installer filename: %s", o.InstallerFilename)
+	}
+	if strings.Contains(strings.TrimPrefix(strings.TrimPrefix(o.MutexName, `Global\`), `Local\`), `\`) {
+		return fmt.Errorf("invalid mutex name: %s", o.MutexName)
+	}
+	for _, address := range o.BootstrapperURLs {
+		u, err := url.Parse(address)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			return fmt.Errorf("invalid bootstrapper URL: %s", address)
+		}
+	}
+	if o.DownloadTimeout < 0 {
+		return fmt.Errorf("invalid download timeout: %s", o.DownloadTimeout)
+	}
+	if o.InstallTimeout < 0 {
+		return fmt.Errorf("invalid install timeout: %s", o.InstallTimeout)
+	}
+	if o.Retry != nil && (o.Retry.MaxAttempts < 0 || o.Retry.InitialBackoff < 0 || o.Retry.MaxBackoff < 0) {
+		return fmt.Errorf("invalid retry policy: %+v", *o.Retry)
+	}
+	if o.Cache != nil && (o.Cache.Dir == "" || o.Cache.MaxAge < 0 || o.Cache.MaxSize < 0) {
+		return fmt.Errorf("invalid cache policy: %+v", *o.Cache)
+	}
+	if o.Proxy != "" && o.SOCKS5Proxy != "" {
+		return fmt.Errorf("only one of Proxy and SOCKS5Proxy may be set")
+	}
+	if o.Proxy != "" {
+		_, err := parseProxy(o.Proxy)
+		if err != nil {
+			return err
+		}
+	}
+	if o.SOCKS5Proxy != "" {
+		_, err := newSOCKS5Dialer(o.SOCKS5Proxy)
+		if err != nil {
+			return err
+		}
+	}
+	if o.SHA256 != "" {
+		hash, err := hex.DecodeString(o.SHA256)
+		if err != nil || len(hash) != sha256.Size {
+			return fmt.Errorf("invalid SHA256 hash: %s", o.SHA256)
+		}
+	}
+	if o.MinimumInstallerVersion != "" {
+		_, err := parseVersion(o.MinimumInstallerVersion)
+		if err != nil {
+			return fmt.Errorf("invalid minimum installer version: %w", err)
+		}
+	}
+	if o.Elevation < ElevationAuto || o.Elevation > ElevationNever {
+		return fmt.Errorf("invalid elevation mode: %d", int(o.Elevation))
+	}
+	if o.Scope < InstallScopeAuto || o.Scope > InstallScopeUser {
+		return fmt.Errorf("invalid install scope: %d", int(o.Scope))
+	}
+	if o.Scope == InstallScopeMachine && o.Elevation == ElevationNever && !IsElevated() {
+		return fmt.Errorf("%w: per-machine installs need elevation", ErrElevationRequired)
+	}
+	if o.Scope == InstallScopeUser && IsElevated() {
+		return fmt.Errorf("per-user installs cannot be run from an elevated process")
+	}
+	if o.CleanupPolicy < CleanupAlways || o.CleanupPolicy > CleanupNever {
+		return fmt.Errorf("invalid cleanup policy: %d", int(o.CleanupPolicy))
+	}
+	for _, arg := range o.ExtraArguments {
+		if arg == "" || strings.ContainsAny(arg, "\x00\r\n") {
+			return fmt.Errorf("invalid extra argument: %q", arg)
+		}
+	}
+	if o.Language != "" {
+		_, err := installLanguage(o.Language)
+		if err != nil {
+			return err
+		}
+	}
+	_, err := channelGUID(o.TargetChannel)
+	if err != nil {
+		return err
+	}
+	if o.TargetChannel == ChannelCanary && o.Scope == InstallScopeMachine {
+		return fmt.Errorf("%s cannot be installed per-machine", o.TargetChannel)
+	}
+	return nil
+}
+
+// arguments returns the command line arguments to pass to the installer.
+func (o *InstallOptions) arguments() []string {
+	if o == nil {
+		return nil
+	}
+	var args []string
+	tag := o.installerTag()
+	switch {
+	case o.Silent:
+		args = append(args, silentSwitches...)
+	case tag != "":
+		args = append(args, "/install")
+	}
+	if tag != "" {
+		args = append(args, tag)
+	}
+	args = append(args, logLevelSwitches[o.LogLevel]...)
+	args = append(args, o.ExtraArguments...)
+	return args
+}
+
+// checkInstaller checks the installer against the SHA256, RequireSignature and MinimumInstallerVersion options.
+func (o *InstallOptions) checkInstaller(installer string) error {
+	if o == nil {
+		return nil
+	}
+	if o.SHA256 != "" {
+		err := checkSHA256(installer, o.SHA256)
+		if err != nil {
+			return err
+		}
+	}
+	if o.RequireSignature {
+		_, err := VerifySignature(installer)
+		if err != nil {
+			return err
+		}
+	}
+	return o.checkInstallerVersion(installer)
+}
+
+// checkInstallerVersion checks the installer version against MinimumInstallerVersion.
+// The check is skipped if the installer has no version resource.
+func (o *InstallOptions) checkInstallerVersion(installer string) error {
+	if o.MinimumInstallerVersion == "" {
+		return nil
+	}
+	version, err := getFileVersion(installer)
+	if err == errNoVersionResource {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to read installer version: %w", err)
+	}
+	result, err := compareVersions(version, o.MinimumInstallerVersion)
+	if err != nil {
+		return err
+	}
+	if result < 0 {
+		return fmt.Errorf("installer version %s is older than the minimum version %s", version, o.MinimumInstallerVersion)
+	}
+	return nil
+}
+
+// checkSHA256 checks the SHA-256 hash of the file matches the expected hex encoded hash.
+func checkSHA256(path string, expected string) error {
+	actual, err := fileSHA256(path)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("SHA256 mismatch for %s: expected %s but found %s", path, strings.ToLower(expected), actual)
+	}
+	return nil
+}
+
+// fileSHA256 returns the hex encoded SHA-256 hash of the file.
+func fileSHA256(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	_, err = io.Copy(hash, file)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,32 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInstallOptionsArgumentsScope(t *testing.T) {
+	tests := []struct {
+		name    string
+		options *InstallOptions
+		want    []string
+	}{
+		{"nil options", nil, nil},
+		{"auto scope", &InstallOptions{Scope: InstallScopeAuto}, nil},
+		{"machine scope", &InstallOptions{Scope: InstallScopeMachine}, []string{"/install", "appguid=" + clientGUID + "&needsadmin=true"}},
+		{"user scope", &InstallOptions{Scope: InstallScopeUser}, []string{"/install", "appguid=" + clientGUID + "&needsadmin=false"}},
+		{"silent auto scope", &InstallOptions{Silent: true}, []string{"/silent", "/install"}},
+		{"silent user scope", &InstallOptions{Silent: true, Scope: InstallScopeUser}, []string{"/silent", "/install", "appguid=" + clientGUID + "&needsadmin=false"}},
+		{"per-user fallback", (&InstallOptions{}).perUser(), []string{"/install", "appguid=" + clientGUID + "&needsadmin=false"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.options.arguments(); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("arguments() = %q, want %q", got, test.want)
+			}
+		})
+	}
+}
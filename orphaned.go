@@ -0,0 +1,71 @@
+package webview2runtime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// runtimeFiles are the files expected in the version folder of a working runtime.
+// msedge.dll and msedge_elf.dll are the shared Edge components the runtime depends on.
+var runtimeFiles = []string{
+	"msedgewebview2.exe",
+	"msedge.dll",
+	"msedge_elf.dll",
+}
+
+// OrphanedRuntime describes a registration of the runtime whose files are missing.
+type OrphanedRuntime struct {
+	Registration
+	// Missing lists the expected files or folders that could not be found.
+	Missing []string
+}
+
+// missingRuntimeFiles returns the expected runtime files and folders missing for the given installation.
+func missingRuntimeFiles(info *Info) []string {
+	if !exists(info.Location) {
+		return []string{info.Location}
+	}
+	versionFolder := filepath.Join(info.Location, info.Version)
+	if !exists(versionFolder) {
+		return []string{versionFolder}
+	}
+	var missing []string
+	for _, file := range runtimeFiles {
+		path := filepath.Join(versionFolder, file)
+		if !exists(path) {
+			missing = append(missing, path)
+		}
+	}
+	return missing
+}
+
+func exists(path string) bool {
+	if path == "" {
+		return false
+	}
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// BrowserExecutablePath returns the path of msedgewebview2.exe for this installation.
+// Evergreen runtimes and Edge channels keep it in the version folder beneath Location, while
+// fixed version runtimes keep it directly in Location.
+// Returns a *MissingFilesError if the executable or the files it depends on are missing.
+func (i *Info) BrowserExecutablePath() (string, error) {
+	if i.Location == "" {
+		return "", fmt.Errorf("%w: no location is recorded for webview2 runtime %s", ErrRuntimeFilesMissing, i.Version)
+	}
+	if i.Scope == ScopeFixedVersion || i.Scope == ScopeEnvironment {
+		path := filepath.Join(i.Location, runtimeExecutable)
+		if !exists(path) {
+			return "", &MissingFilesError{Version: i.Version, Missing: []string{path}}
+		}
+		return path, nil
+	}
+	missing := missingRuntimeFiles(i)
+	if len(missing) > 0 {
+		return "", &MissingFilesError{Version: i.Version, Missing: missing}
+	}
+	return filepath.Join(i.Location, i.Version, runtimeExecutable), nil
+}
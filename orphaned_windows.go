@@ -0,0 +1,31 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+// DetectOrphanedRuntime reports registrations of the runtime whose files are missing from disk.
+// This typically happens when uninstalling Edge removes the shared components WebView2 depends on,
+// leaving the EdgeUpdate client key behind. Apps can use this to recommend reinstalling the runtime.
+//
+// The heuristic used is: a registration is orphaned if its Location folder does not exist, or the
+// version folder beneath it (`<Location>\<Version>`) is missing any of msedgewebview2.exe,
+// msedge.dll or msedge_elf.dll.
+//
+// Returns nil if all registrations look healthy.
+func DetectOrphanedRuntime() ([]OrphanedRuntime, error) {
+	registrations, err := defaultDetector.Registrations()
+	if err != nil {
+		return nil, err
+	}
+	var result []OrphanedRuntime
+	for _, registration := range registrations {
+		missing := missingRuntimeFiles(&registration.Info)
+		if len(missing) > 0 {
+			result = append(result, OrphanedRuntime{
+				Registration: registration,
+				Missing:      missing,
+			})
+		}
+	}
+	return result, nil
+}
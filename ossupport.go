@@ -0,0 +1,21 @@
+package webview2runtime
+
+// legacyOSLastRuntime is the last major version of the runtime that supports Windows 7, 8 and 8.1,
+// and the matching server versions.
+const legacyOSLastRuntime = "109"
+
+// OSSupport explains whether the running version of Windows supports the runtime.
+type OSSupport struct {
+	// OSVersion is the version of Windows, eg `10.0.19045`.
+	OSVersion string
+	// LastSupportedRuntime is the newest major version of the runtime that supports this version
+	// of Windows, eg `109`. Blank if current versions of the runtime are supported.
+	LastSupportedRuntime string
+	// Message describes the level of support, suitable for showing to the user.
+	Message string
+}
+
+// String returns the message.
+func (s OSSupport) String() string {
+	return s.Message
+}
@@ -0,0 +1,35 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"fmt"
+	"golang.org/x/sys/windows"
+)
+
+// IsOSSupported returns true if the runtime can be installed on the running version of Windows,
+// which needs Windows 7 SP1, Windows Server 2008 R2 SP1 or newer. Windows 7, 8 and 8.1, and
+// the matching server versions, only support runtime 109 and older, which is recorded in the
+// LastSupportedRuntime of the returned OSSupport.
+func IsOSSupported() (bool, OSSupport) {
+	return osSupport(windows.RtlGetVersion())
+}
+
+func osSupport(version *windows.OsVersionInfoEx) (bool, OSSupport) {
+	support := OSSupport{
+		OSVersion: fmt.Sprintf("%d.%d.%d", version.MajorVersion, version.MinorVersion, version.BuildNumber),
+	}
+	switch {
+	case version.MajorVersion < 6 || version.MajorVersion == 6 && version.MinorVersion < 1 ||
+		version.MajorVersion == 6 && version.MinorVersion == 1 && version.ServicePackMajor < 1:
+		support.Message = fmt.Sprintf("Windows %s is too old for the WebView2 runtime, which requires Windows 7 SP1 or newer", support.OSVersion)
+		return false, support
+	case version.MajorVersion < 10:
+		support.LastSupportedRuntime = legacyOSLastRuntime
+		support.Message = fmt.Sprintf("Windows %s is only supported by WebView2 runtime %s and older", support.OSVersion, legacyOSLastRuntime)
+		return true, support
+	}
+	support.Message = fmt.Sprintf("Windows %s is supported by the WebView2 runtime", support.OSVersion)
+	return true, support
+}
@@ -0,0 +1,60 @@
+package webview2runtime
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// perUserInstallFolder returns the folder EdgeUpdate uses for per-user installs of the runtime.
+func perUserInstallFolder() (string, error) {
+	localAppData := os.Getenv("LOCALAPPDATA")
+	if localAppData == "" {
+		return "", fmt.Errorf("LOCALAPPDATA is not set")
+	}
+	return filepath.Join(localAppData, "Microsoft", "EdgeWebView"), nil
+}
+
+// CanInstallPerUser is the same as the package level CanInstallPerUser but uses this Detector.
+func (d *Detector) CanInstallPerUser() (bool, error) {
+	policy, err := d.installPolicy()
+	if err != nil {
+		return false, fmt.Errorf("unable to read install policy: %w", err)
+	}
+	switch policy {
+	case InstallPolicyDisabled:
+		return false, fmt.Errorf("installing the webview2 runtime is disabled by group policy")
+	case InstallPolicyMachineOnly:
+		return false, fmt.Errorf("group policy only allows per-machine installs of the webview2 runtime")
+	}
+
+	folder, err := perUserInstallFolder()
+	if err != nil {
+		return false, err
+	}
+	err = checkWritable(folder)
+	if err != nil {
+		return false, fmt.Errorf("per-user install location is not writable: %w", err)
+	}
+	return true, nil
+}
+
+// checkWritable checks a file can be created in the given folder, creating the folder if needed.
+// Folders created by the check are removed afterwards.
+func checkWritable(folder string) error {
+	created := !exists(folder)
+	err := os.MkdirAll(folder, 0755)
+	if err != nil {
+		return err
+	}
+	if created {
+		defer os.Remove(folder)
+	}
+	file, err := os.CreateTemp(folder, "webview2runtime-*.tmp")
+	if err != nil {
+		return err
+	}
+	name := file.Name()
+	file.Close()
+	return os.Remove(name)
+}
@@ -0,0 +1,11 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+// CanInstallPerUser reports whether the runtime can be installed for the current user without elevation.
+// It checks the EdgeUpdate install group policies and that the per-user install location is writable.
+// Returns false and an error describing the blocking reason if a per-user install would fail.
+func CanInstallPerUser() (bool, error) {
+	return defaultDetector.CanInstallPerUser()
+}
@@ -0,0 +1,93 @@
+package webview2runtime
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PlanAction is the kind of a PlanStep.
+type PlanAction int
+
+const (
+	// ActionReadRegistry reads a registry key.
+	ActionReadRegistry PlanAction = iota
+	// ActionWriteRegistry is a registry key the installer or uninstaller is expected to write or remove.
+	ActionWriteRegistry
+	// ActionReadFile reads a file or folder.
+	ActionReadFile
+	// ActionDownload downloads a URL.
+	ActionDownload
+	// ActionWriteFile writes a file.
+	ActionWriteFile
+	// ActionRun runs a command.
+	ActionRun
+	// ActionDeleteFile deletes a file.
+	ActionDeleteFile
+	// ActionLock acquires a mutex or lock file.
+	ActionLock
+)
+
+// String returns the name of the action.
+func (a PlanAction) String() string {
+	switch a {
+	case ActionReadRegistry:
+		return "read registry"
+	case ActionWriteRegistry:
+		return "write registry"
+	case ActionReadFile:
+		return "read file"
+	case ActionDownload:
+		return "download"
+	case ActionWriteFile:
+		return "write file"
+	case ActionRun:
+		return "run"
+	case ActionDeleteFile:
+		return "delete file"
+	case ActionLock:
+		return "lock"
+	}
+	return "unknown"
+}
+
+// PlanStep is a single action that would be taken.
+type PlanStep struct {
+	Action PlanAction
+	// Target is what the action applies to, eg a URL, path or registry key.
+	Target string
+	// Detail describes the action further, eg the arguments of a command.
+	Detail string
+}
+
+// Plan is what an install, repair or uninstall would do, created when the DryRun option is set.
+type Plan struct {
+	Steps []PlanStep
+}
+
+// add adds a step to the plan and logs it.
+func (p *Plan) add(action PlanAction, target string, detail string) {
+	p.Steps = append(p.Steps, PlanStep{Action: action, Target: target, Detail: detail})
+	logEvent("dry run", "action", action, "target", target, "detail", detail)
+}
+
+// String returns the plan as human readable text, one step per line.
+func (p *Plan) String() string {
+	var builder strings.Builder
+	for i, step := range p.Steps {
+		fmt.Fprintf(&builder, "%d. %s %s", i+1, step.Action, step.Target)
+		if step.Detail != "" {
+			fmt.Fprintf(&builder, " (%s)", step.Detail)
+		}
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// installSource is where the installer of a planned install comes from.
+type installSource int
+
+const (
+	sourceDownload installSource = iota
+	sourceProvided
+	sourceStandalone
+)
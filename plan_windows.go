@@ -0,0 +1,106 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"fmt"
+	"golang.org/x/sys/windows"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// addDetect adds the registry reads made to detect the installed runtime.
+func (p *Plan) addDetect() {
+	for _, key := range runtimeKeys(nativeArchitecture()) {
+		p.add(ActionReadRegistry, key, "detect the installed runtime")
+	}
+}
+
+// addRun adds running the program with the given options.
+func (p *Plan) addRun(program string, args []string, options *InstallOptions) {
+	method := "exec"
+	switch {
+	case options != nil && options.Runner != nil:
+		method = "the custom runner"
+	case !IsElevated() && options.elevation() == ElevationAuto && options.commandHook() == nil:
+		method = "runas, prompting for elevation"
+	}
+	p.add(ActionRun, program, fmt.Sprintf("arguments %q, started with %s", strings.Join(args, " "), method))
+}
+
+// addRegistration adds the registration of the runtime expected to be written or removed for the given scope.
+func (p *Plan) addRegistration(scope InstallScope, detail string) {
+	if scope != InstallScopeUser {
+		p.add(ActionWriteRegistry, machineWOW64ClientsKey+clientGUID, detail+" for per-machine installs")
+	}
+	if scope != InstallScopeMachine {
+		p.add(ActionWriteRegistry, currentUserClientsKey+clientGUID, detail+" for per-user installs")
+	}
+}
+
+// planInstall returns the result of a dry run install: a result with the plan of what the install
+// would do. Nothing is installed, so Success is false.
+func planInstall(source installSource, installer string, options *InstallOptions) (*InstallResult, error) {
+	plan := &Plan{}
+	plan.addDetect()
+	plan.add(ActionLock, options.mutexName(), "mutex")
+	if lockFile := options.lockFile(); lockFile != "" {
+		plan.add(ActionLock, lockFile, "lock file")
+	}
+
+	temporary := source != sourceStandalone
+	if temporary {
+		installer = filepath.Join(os.TempDir(), installerFilenamePattern)
+		if options != nil && options.InstallerFilename != "" {
+			installer = filepath.Join(os.TempDir(), options.InstallerFilename)
+		}
+	}
+	switch source {
+	case sourceDownload:
+		if cache := options.cachePolicy(); cache != nil {
+			plan.add(ActionReadFile, cache.Dir, "use a cached installer if there is one")
+		}
+		for _, url := range options.bootstrapperURLs() {
+			plan.add(ActionDownload, url, "to "+installer+", trying each URL until one succeeds")
+		}
+	case sourceProvided:
+		plan.add(ActionWriteFile, installer, "the bootstrapper")
+	}
+
+	plan.addRun(installer, options.arguments(), options)
+	plan.addRegistration(options.scope(), "registered by the installer")
+	if options.cleanupPolicy() != CleanupNever {
+		if temporary {
+			plan.add(ActionDeleteFile, installer, "cleanup policy "+options.cleanupPolicy().String())
+		}
+		if options != nil && options.LogLevel != LogLevelNone {
+			for _, log := range installerTempLogPaths() {
+				plan.add(ActionDeleteFile, log, "if written by this install, cleanup policy "+options.cleanupPolicy().String())
+			}
+		}
+	}
+	return &InstallResult{Installer: installer, Plan: plan}, nil
+}
+
+// UninstallPlan returns what Uninstall would do, without uninstalling anything.
+func (i *Info) UninstallPlan() (*Plan, error) {
+	if i.SilentUninstall == "" {
+		return nil, fmt.Errorf("no uninstall command is recorded for webview2 runtime %s", i.Version)
+	}
+	args, err := windows.DecomposeCommandLine(i.SilentUninstall)
+	if err != nil || len(args) == 0 {
+		return nil, fmt.Errorf("invalid uninstall command: %s", i.SilentUninstall)
+	}
+	options := &InstallOptions{HideWindow: true}
+	scope := InstallScopeMachine
+	if i.Scope == ScopeUser {
+		options.Elevation = ElevationNever
+		scope = InstallScopeUser
+	}
+	plan := &Plan{}
+	plan.addRun(args[0], args[1:], options)
+	plan.addRegistration(scope, "removed by the uninstaller")
+	return plan, nil
+}
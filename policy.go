@@ -0,0 +1,222 @@
+package webview2runtime
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// edgeUpdatePolicyKey holds the EdgeUpdate group policies.
+const edgeUpdatePolicyKey = `HKLM\SOFTWARE\Policies\Microsoft\EdgeUpdate`
+
+// InstallPolicy is the value of the EdgeUpdate install group policy.
+type InstallPolicy int
+
+const (
+	// InstallPolicyNotConfigured means no install policy is set.
+	InstallPolicyNotConfigured InstallPolicy = -1
+	// InstallPolicyDisabled prevents the runtime being installed.
+	InstallPolicyDisabled InstallPolicy = 0
+	// InstallPolicyAlwaysAllow allows both per-machine and per-user installs.
+	InstallPolicyAlwaysAllow InstallPolicy = 1
+	// InstallPolicyMachineOnly allows only per-machine installs.
+	InstallPolicyMachineOnly InstallPolicy = 2
+	// InstallPolicyUserOnly allows only per-user installs.
+	InstallPolicyUserOnly InstallPolicy = 3
+)
+
+// UpdatePolicy is the effective EdgeUpdate update group policy of an installation.
+// Unlike InstallPolicy, the zero value means no policy is configured.
+type UpdatePolicy int
+
+const (
+	// UpdatePolicyNotConfigured means no update policy is set, so updates are automatic.
+	UpdatePolicyNotConfigured UpdatePolicy = iota
+	// UpdatePolicyDisabled prevents the runtime being updated. Policy value 0.
+	UpdatePolicyDisabled
+	// UpdatePolicyAlwaysAllow allows both automatic and manual updates. Policy value 1.
+	UpdatePolicyAlwaysAllow
+	// UpdatePolicyManualOnly allows only manual updates. Policy value 2.
+	UpdatePolicyManualOnly
+	// UpdatePolicyAutomaticOnly allows only automatic, silent updates. Policy value 3.
+	UpdatePolicyAutomaticOnly
+)
+
+// updatePolicyValues maps the values of the update policies to an UpdatePolicy.
+var updatePolicyValues = map[string]UpdatePolicy{
+	"0": UpdatePolicyDisabled,
+	"1": UpdatePolicyAlwaysAllow,
+	"2": UpdatePolicyManualOnly,
+	"3": UpdatePolicyAutomaticOnly,
+}
+
+// String returns the name of the update policy.
+func (p UpdatePolicy) String() string {
+	switch p {
+	case UpdatePolicyNotConfigured:
+		return "not configured"
+	case UpdatePolicyDisabled:
+		return "disabled"
+	case UpdatePolicyAlwaysAllow:
+		return "always allow"
+	case UpdatePolicyManualOnly:
+		return "manual only"
+	case UpdatePolicyAutomaticOnly:
+		return "automatic only"
+	}
+	return fmt.Sprintf("UpdatePolicy(%d)", int(p))
+}
+
+// updatesDisabledByPolicy returns true if the EdgeUpdate policies stop the runtime being updated,
+// either by disabling updates or only allowing manual updates.
+func (d *Detector) updatesDisabledByPolicy() (bool, error) {
+	policy, err := d.updatePolicy(clientGUID)
+	if err != nil {
+		return false, err
+	}
+	return policy == UpdatePolicyDisabled || policy == UpdatePolicyManualOnly, nil
+}
+
+// updatePolicy returns the effective update policy for the EdgeUpdate client with the given ID.
+// The client specific `Update{GUID}` policy takes precedence over `UpdateDefault`.
+func (d *Detector) updatePolicy(guid string) (UpdatePolicy, error) {
+	values, err := d.readValues(edgeUpdatePolicyKey)
+	if err != nil {
+		return UpdatePolicyNotConfigured, err
+	}
+	for _, name := range []string{"Update" + guid, "UpdateDefault"} {
+		policy, ok := updatePolicyValues[values[name]]
+		if !ok {
+			continue
+		}
+		return policy, nil
+	}
+	return UpdatePolicyNotConfigured, nil
+}
+
+// installPolicy returns the effective install policy for the runtime.
+// The runtime specific `Install{GUID}` policy takes precedence over `InstallDefault`.
+func (d *Detector) installPolicy() (InstallPolicy, error) {
+	values, err := d.readValues(edgeUpdatePolicyKey)
+	if err != nil {
+		return InstallPolicyNotConfigured, err
+	}
+	for _, name := range []string{"Install" + clientGUID, "InstallDefault"} {
+		value, ok := values[name]
+		if !ok {
+			continue
+		}
+		policy, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+		return InstallPolicy(policy), nil
+	}
+	return InstallPolicyNotConfigured, nil
+}
+
+// PolicyEffect is how a group policy affects installing or running the runtime.
+type PolicyEffect int
+
+const (
+	// PolicyBlocksInstall means the runtime cannot be installed.
+	PolicyBlocksInstall PolicyEffect = iota
+	// PolicyRestrictsInstall means the runtime can only be installed per-machine or per-user.
+	PolicyRestrictsInstall
+	// PolicyAltersUpdates means the runtime is not updated normally.
+	PolicyAltersUpdates
+	// PolicyAltersRuntime means apps use a different runtime to the installed one.
+	PolicyAltersRuntime
+)
+
+// String returns the name of the effect.
+func (e PolicyEffect) String() string {
+	switch e {
+	case PolicyBlocksInstall:
+		return "blocks install"
+	case PolicyRestrictsInstall:
+		return "restricts install"
+	case PolicyAltersUpdates:
+		return "alters updates"
+	case PolicyAltersRuntime:
+		return "alters runtime"
+	}
+	return "unknown"
+}
+
+// PolicyFinding is a group policy that prevents or alters an install of the runtime.
+type PolicyFinding struct {
+	// Name is the name of the policy value.
+	Name string
+	// Value is the configured value.
+	Value  string
+	Effect PolicyEffect
+	// Message describes the effect of the policy, suitable for showing to the user.
+	Message string
+}
+
+// CheckPolicies returns the group policies that would prevent or alter an install of the runtime.
+func (d *Detector) CheckPolicies() ([]PolicyFinding, error) {
+	values, err := d.readValues(edgeUpdatePolicyKey)
+	if err != nil {
+		return nil, err
+	}
+	var findings []PolicyFinding
+	add := func(name string, effect PolicyEffect, message string) {
+		findings = append(findings, PolicyFinding{Name: name, Value: values[name], Effect: effect, Message: message})
+	}
+
+	for _, name := range []string{"Install" + clientGUID, "InstallDefault"} {
+		value, ok := values[name]
+		if !ok {
+			continue
+		}
+		switch value {
+		case strconv.Itoa(int(InstallPolicyDisabled)):
+			add(name, PolicyBlocksInstall, "Installing the WebView2 runtime is disabled by group policy")
+		case strconv.Itoa(int(InstallPolicyMachineOnly)):
+			add(name, PolicyRestrictsInstall, "Group policy only allows per-machine installs of the WebView2 runtime, which need administrator rights")
+		case strconv.Itoa(int(InstallPolicyUserOnly)):
+			add(name, PolicyRestrictsInstall, "Group policy only allows per-user installs of the WebView2 runtime")
+		}
+		break
+	}
+	for _, name := range []string{"Update" + clientGUID, "UpdateDefault"} {
+		value, ok := values[name]
+		if !ok {
+			continue
+		}
+		switch value {
+		case "0":
+			add(name, PolicyAltersUpdates, "Updating the WebView2 runtime is disabled by group policy")
+		case "2":
+			add(name, PolicyAltersUpdates, "Group policy only allows manual updates of the WebView2 runtime")
+		}
+		break
+	}
+	if value := values["TargetVersionPrefix"+clientGUID]; value != "" {
+		add("TargetVersionPrefix"+clientGUID, PolicyAltersUpdates, fmt.Sprintf("Group policy pins the WebView2 runtime to version %s", value))
+	}
+	if value := values["TargetChannel"+clientGUID]; value != "" {
+		add("TargetChannel"+clientGUID, PolicyAltersUpdates, fmt.Sprintf("Group policy sets the WebView2 runtime channel to %s", value))
+	}
+	if values["RollbackToTargetVersion"+clientGUID] == "1" {
+		add("RollbackToTargetVersion"+clientGUID, PolicyAltersUpdates, "Group policy rolls the WebView2 runtime back to the target version")
+	}
+	if values["AutoUpdateCheckPeriodMinutes"] == "0" {
+		add("AutoUpdateCheckPeriodMinutes", PolicyAltersUpdates, "Automatic update checks are disabled by group policy")
+	}
+
+	folder, err := d.browserExecutableFolderPolicy()
+	if err != nil {
+		return nil, err
+	}
+	if folder != "" {
+		findings = append(findings, PolicyFinding{
+			Name:    "BrowserExecutableFolder",
+			Value:   folder,
+			Effect:  PolicyAltersRuntime,
+			Message: fmt.Sprintf("Group policy makes this app use the runtime in %s", folder),
+		})
+	}
+	return findings, nil
+}
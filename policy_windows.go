@@ -0,0 +1,13 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+// CheckPolicies returns the group policies that would prevent or alter an install of the runtime:
+// install and update policies, version pinning and channel overrides from EdgeUpdate, and the
+// WebView2 BrowserExecutableFolder policy.
+// Returns nil if no relevant policies are set.
+// Returns an error if the registry could not be read.
+func CheckPolicies() ([]PolicyFinding, error) {
+	return defaultDetector.CheckPolicies()
+}
@@ -0,0 +1,92 @@
+package webview2runtime
+
+import (
+	"fmt"
+)
+
+// rebootPendingKeys are the registry keys that exist while Windows is waiting for a reboot.
+var rebootPendingKeys = []string{
+	`HKLM\SOFTWARE\Microsoft\Windows\CurrentVersion\Component Based Servicing\RebootPending`,
+	`HKLM\SOFTWARE\Microsoft\Windows\CurrentVersion\WindowsUpdate\Auto Update\RebootRequired`,
+}
+
+// sessionManagerKey holds the file renames Windows will make on the next reboot.
+const sessionManagerKey = `HKLM\SYSTEM\CurrentControlSet\Control\Session Manager`
+
+// PreflightCheck identifies a check made by Preflight.
+type PreflightCheck int
+
+const (
+	// CheckDiskSpace checks the free space on the system drive and the temp directory.
+	CheckDiskSpace PreflightCheck = iota
+	// CheckOSVersion checks the version of Windows supports the runtime.
+	CheckOSVersion
+	// CheckPendingReboot checks whether Windows is waiting for a reboot.
+	CheckPendingReboot
+	// CheckConnectivity checks the download endpoint can be reached.
+	CheckConnectivity
+)
+
+// String returns the name of the check.
+func (c PreflightCheck) String() string {
+	switch c {
+	case CheckDiskSpace:
+		return "disk space"
+	case CheckOSVersion:
+		return "os version"
+	case CheckPendingReboot:
+		return "pending reboot"
+	case CheckConnectivity:
+		return "connectivity"
+	}
+	return "unknown"
+}
+
+// PreflightIssue is a problem found by Preflight.
+type PreflightIssue struct {
+	Check PreflightCheck
+	// Message describes the problem, suitable for showing to the user.
+	Message string
+	// Err is the underlying error, if any.
+	Err error
+}
+
+// PreflightReport is the result of Preflight.
+type PreflightReport struct {
+	// Blockers are problems that will stop the install succeeding.
+	Blockers []PreflightIssue
+	// Warnings are problems that may stop the install succeeding.
+	Warnings []PreflightIssue
+}
+
+// OK returns true if no blockers were found.
+func (r *PreflightReport) OK() bool {
+	return len(r.Blockers) == 0
+}
+
+func (r *PreflightReport) block(check PreflightCheck, err error, format string, args ...interface{}) {
+	r.Blockers = append(r.Blockers, PreflightIssue{Check: check, Message: fmt.Sprintf(format, args...), Err: err})
+}
+
+func (r *PreflightReport) warn(check PreflightCheck, err error, format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, PreflightIssue{Check: check, Message: fmt.Sprintf(format, args...), Err: err})
+}
+
+// rebootPending returns true if Windows is waiting for a reboot to complete an update or install.
+func (d *Detector) rebootPending() (bool, error) {
+	for _, key := range rebootPendingKeys {
+		values, err := d.readValues(key)
+		if err != nil {
+			return false, err
+		}
+		if values != nil {
+			return true, nil
+		}
+	}
+	values, err := d.readValues(sessionManagerKey)
+	if err != nil {
+		return false, err
+	}
+	_, pending := values["PendingFileRenameOperations"]
+	return pending, nil
+}
@@ -0,0 +1,66 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+	"os"
+)
+
+// The free disk space needed to install the runtime. Below minimumFreeSpace the install is
+// blocked, below recommendedFreeSpace a warning is given.
+const (
+	minimumFreeSpace     = 500 * 1024 * 1024
+	recommendedFreeSpace = 2 * 1024 * 1024 * 1024
+)
+
+// Preflight checks the machine is ready to install the runtime: that there is enough free space on
+// the system drive and in the temp directory, that the version of Windows is supported, that no
+// reboot is pending and that the download endpoint can be reached using the given options.
+// Checks that cannot be made are reported as warnings. The options may be nil.
+func Preflight(ctx context.Context, options *InstallOptions) *PreflightReport {
+	report := &PreflightReport{}
+
+	dirs := []string{os.TempDir()}
+	if systemDrive := os.Getenv("SystemDrive"); systemDrive != "" {
+		dirs = append(dirs, systemDrive+`\`)
+	}
+	for _, dir := range dirs {
+		free, err := freeDiskSpace(dir)
+		switch {
+		case err != nil:
+			report.warn(CheckDiskSpace, err, "Unable to check the free space of %s", dir)
+		case free < minimumFreeSpace:
+			report.block(CheckDiskSpace, nil, "There is not enough free space on %s: %d MB free, %d MB needed", dir, free/(1024*1024), minimumFreeSpace/(1024*1024))
+		case free < recommendedFreeSpace:
+			report.warn(CheckDiskSpace, nil, "There is little free space on %s: %d MB free", dir, free/(1024*1024))
+		}
+	}
+
+	supported, support := IsOSSupported()
+	switch {
+	case !supported:
+		report.block(CheckOSVersion, nil, "%s", support.Message)
+	case support.LastSupportedRuntime != "":
+		report.warn(CheckOSVersion, nil, "%s", support.Message)
+	}
+
+	pending, err := defaultDetector.rebootPending()
+	switch {
+	case err != nil:
+		report.warn(CheckPendingReboot, err, "Unable to check for a pending reboot")
+	case pending:
+		report.warn(CheckPendingReboot, nil, "Windows is waiting for a reboot, which may stop the install")
+	}
+
+	client, err := options.httpClient()
+	if err == nil {
+		err = checkConnectivity(ctx, client, options.bootstrapperURLs()[0])
+	}
+	if err != nil {
+		report.warn(CheckConnectivity, err, "Unable to reach the WebView2 download server")
+	}
+	logEvent("preflight", "blockers", len(report.Blockers), "warnings", len(report.Warnings))
+	return report
+}
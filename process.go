@@ -0,0 +1,41 @@
+package webview2runtime
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// runtimeExecutable is the name of the webview2 runtime browser process.
+const runtimeExecutable = "msedgewebview2.exe"
+
+// versionFromFolder returns the name of the given folder if it looks like a runtime version number.
+// The runtime binaries live in a folder named after their version, eg `...\Application\91.0.864.59`.
+func versionFromFolder(folder string) string {
+	version := filepath.Base(folder)
+	parts := strings.Split(version, ".")
+	if len(parts) != 4 {
+		return ""
+	}
+	for _, part := range parts {
+		if part == "" || strings.Trim(part, "0123456789") != "" {
+			return ""
+		}
+	}
+	return version
+}
+
+// ProcessInfo describes a running msedgewebview2.exe process.
+type ProcessInfo struct {
+	PID       uint32
+	ParentPID uint32
+	// Path is the path of the executable. Blank if the process could not be inspected.
+	Path string
+	// Version is the version of the runtime the process was started from.
+	Version string
+	// Type is the Chromium process type, eg `renderer` or `gpu-process`. Blank for the browser process.
+	Type string
+	// UserDataFolder is the user data folder given on the command line. Usually only set for the browser process.
+	UserDataFolder string
+	// HostExecutable is the name of the executable of the app hosting the runtime, eg `myapp.exe`.
+	HostExecutable string
+}
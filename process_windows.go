@@ -0,0 +1,189 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"errors"
+	"fmt"
+	"golang.org/x/sys/windows"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	modkernel32        = syscall.NewLazyDLL("kernel32.dll")
+	procModule32FirstW = modkernel32.NewProc("Module32FirstW")
+	procModule32NextW  = modkernel32.NewProc("Module32NextW")
+)
+
+// MODULEENTRY32W struct
+type _MODULEENTRY32W struct {
+	dwSize        uint32
+	th32ModuleID  uint32
+	th32ProcessID uint32
+	glblcntUsage  uint32
+	proccntUsage  uint32
+	modBaseAddr   uintptr
+	modBaseSize   uint32
+	hModule       windows.Handle
+	szModule      [256]uint16
+	szExePath     [windows.MAX_PATH]uint16
+}
+
+// GetProcessRuntime returns the runtime used by the running msedgewebview2.exe process with the given PID.
+// The returned Info has the Location and Version of the runtime the process was started from.
+// If the modules of a protected process cannot be enumerated, the process image path is used instead.
+// Returns an error if the process could not be inspected or is not a webview2 runtime process.
+func GetProcessRuntime(pid uint32) (*Info, error) {
+	path, err := processModulePath(pid, runtimeExecutable)
+	if errors.Is(err, windows.ERROR_ACCESS_DENIED) {
+		path, err = processImagePath(pid)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to inspect process %d: %w", pid, err)
+	}
+	if !strings.EqualFold(filepath.Base(path), runtimeExecutable) {
+		return nil, fmt.Errorf("process %d is not a webview2 runtime process: %s", pid, path)
+	}
+	location := filepath.Dir(path)
+	return &Info{
+		Location: location,
+		Version:  versionFromFolder(location),
+	}, nil
+}
+
+// processModulePath enumerates the modules of the given process and returns the path of the named module.
+func processModulePath(pid uint32, module string) (string, error) {
+	snapshot, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPMODULE|windows.TH32CS_SNAPMODULE32, pid)
+	if err != nil {
+		return "", err
+	}
+	defer windows.CloseHandle(snapshot)
+
+	var entry _MODULEENTRY32W
+	entry.dwSize = uint32(unsafe.Sizeof(entry))
+	ret, _, err := procModule32FirstW.Call(uintptr(snapshot), uintptr(unsafe.Pointer(&entry)))
+	for ret != 0 {
+		if strings.EqualFold(windows.UTF16ToString(entry.szModule[:]), module) {
+			return windows.UTF16ToString(entry.szExePath[:]), nil
+		}
+		ret, _, err = procModule32NextW.Call(uintptr(snapshot), uintptr(unsafe.Pointer(&entry)))
+	}
+	if err == windows.ERROR_NO_MORE_FILES {
+		return "", fmt.Errorf("module %s not loaded", module)
+	}
+	return "", err
+}
+
+// processImagePath returns the path of the executable of the given process.
+// This only requires limited query rights, so works for more processes than module enumeration.
+func processImagePath(pid uint32) (string, error) {
+	process, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		return "", err
+	}
+	defer windows.CloseHandle(process)
+
+	buffer := make([]uint16, windows.MAX_LONG_PATH)
+	size := uint32(len(buffer))
+	err = windows.QueryFullProcessImageName(process, 0, &buffer[0], &size)
+	if err != nil {
+		return "", err
+	}
+	return windows.UTF16ToString(buffer[:size]), nil
+}
+
+// ListRuntimeProcesses returns the running msedgewebview2.exe processes, showing which apps are using
+// which version of the runtime. Details that cannot be read, eg for processes of other users, are left blank.
+func ListRuntimeProcesses() ([]ProcessInfo, error) {
+	snapshot, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return nil, fmt.Errorf("unable to list processes: %w", err)
+	}
+	defer windows.CloseHandle(snapshot)
+
+	var processes []ProcessInfo
+	var entry windows.ProcessEntry32
+	entry.Size = uint32(unsafe.Sizeof(entry))
+	err = windows.Process32First(snapshot, &entry)
+	for err == nil {
+		if strings.EqualFold(windows.UTF16ToString(entry.ExeFile[:]), runtimeExecutable) {
+			processes = append(processes, inspectRuntimeProcess(entry.ProcessID, entry.ParentProcessID))
+		}
+		err = windows.Process32Next(snapshot, &entry)
+	}
+	if err != windows.ERROR_NO_MORE_FILES {
+		return nil, fmt.Errorf("unable to list processes: %w", err)
+	}
+	return processes, nil
+}
+
+// inspectRuntimeProcess reads what it can about the given runtime process.
+func inspectRuntimeProcess(pid uint32, parentPID uint32) ProcessInfo {
+	process := ProcessInfo{PID: pid, ParentPID: parentPID}
+	path, err := processImagePath(pid)
+	if err == nil {
+		process.Path = path
+		process.Version = versionFromFolder(filepath.Dir(path))
+	}
+	commandLine, err := processCommandLine(pid)
+	if err != nil {
+		return process
+	}
+	args, err := windows.DecomposeCommandLine(commandLine)
+	if err != nil {
+		return process
+	}
+	for _, arg := range args {
+		switch {
+		case strings.HasPrefix(arg, "--type="):
+			process.Type = strings.TrimPrefix(arg, "--type=")
+		case strings.HasPrefix(arg, "--user-data-dir="):
+			process.UserDataFolder = strings.TrimPrefix(arg, "--user-data-dir=")
+		case strings.HasPrefix(arg, "--webview-exe-name="):
+			process.HostExecutable = strings.TrimPrefix(arg, "--webview-exe-name=")
+		}
+	}
+	return process
+}
+
+// processCommandLine returns the command line of the given process. Requires Windows 8.1 or newer.
+func processCommandLine(pid uint32) (string, error) {
+	process, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		return "", err
+	}
+	defer windows.CloseHandle(process)
+
+	// The result is a UNICODE_STRING followed by the command line it points to
+	buffer := make([]byte, 64*1024)
+	var size uint32
+	err = windows.NtQueryInformationProcess(process, windows.ProcessCommandLineInformation, unsafe.Pointer(&buffer[0]), uint32(len(buffer)), &size)
+	if err != nil {
+		return "", err
+	}
+	commandLine := (*windows.NTUnicodeString)(unsafe.Pointer(&buffer[0]))
+	length := int(commandLine.Length / 2)
+	if commandLine.Buffer == nil || length == 0 {
+		return "", nil
+	}
+	return windows.UTF16ToString((*[1 << 28]uint16)(unsafe.Pointer(commandLine.Buffer))[:length:length]), nil
+}
+
+// Processes returns the running msedgewebview2.exe processes started from this installation.
+func (i *Info) Processes() ([]ProcessInfo, error) {
+	processes, err := ListRuntimeProcesses()
+	if err != nil {
+		return nil, err
+	}
+	var result []ProcessInfo
+	for _, process := range processes {
+		if process.Path != "" && i.Location != "" && strings.HasPrefix(strings.ToLower(process.Path), strings.ToLower(i.Location)+`\`) {
+			result = append(result, process)
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,47 @@
+package webview2runtime
+
+// Phase is a stage of an install.
+type Phase int
+
+const (
+	// PhaseDownloading is reported when the installer download starts.
+	PhaseDownloading Phase = iota
+	// PhaseInstalling is reported when the installer is started.
+	PhaseInstalling
+	// PhaseVerifying is reported when the installed version is being verified.
+	PhaseVerifying
+	// PhaseCleaningUp is reported when the installer files are being removed.
+	PhaseCleaningUp
+	// PhaseComplete is reported once the install has finished, whether or not it succeeded.
+	PhaseComplete
+)
+
+// String returns the name of the phase.
+func (p Phase) String() string {
+	switch p {
+	case PhaseDownloading:
+		return "downloading"
+	case PhaseInstalling:
+		return "installing"
+	case PhaseVerifying:
+		return "verifying"
+	case PhaseCleaningUp:
+		return "cleaning up"
+	case PhaseComplete:
+		return "complete"
+	}
+	return "unknown"
+}
+
+// progressWriter reports the number of bytes written to it.
+type progressWriter struct {
+	downloaded int64
+	total      int64
+	callback   func(downloaded int64, total int64)
+}
+
+func (p *progressWriter) Write(data []byte) (int, error) {
+	p.downloaded += int64(len(data))
+	p.callback(p.downloaded, p.total)
+	return len(data), nil
+}
@@ -0,0 +1,357 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+	"fmt"
+	"golang.org/x/sys/windows"
+	"runtime"
+	"sync"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	moduser32                = syscall.NewLazyDLL("user32.dll")
+	procMessageBoxW          = moduser32.NewProc("MessageBoxW")
+	procRegisterClassExW     = moduser32.NewProc("RegisterClassExW")
+	procCreateWindowExW      = moduser32.NewProc("CreateWindowExW")
+	procDefWindowProcW       = moduser32.NewProc("DefWindowProcW")
+	procDestroyWindow        = moduser32.NewProc("DestroyWindow")
+	procGetMessageW          = moduser32.NewProc("GetMessageW")
+	procTranslateMessage     = moduser32.NewProc("TranslateMessage")
+	procDispatchMessageW     = moduser32.NewProc("DispatchMessageW")
+	procPostMessageW         = moduser32.NewProc("PostMessageW")
+	procSendMessageW         = moduser32.NewProc("SendMessageW")
+	procPostQuitMessage      = moduser32.NewProc("PostQuitMessage")
+	procSetWindowTextW       = moduser32.NewProc("SetWindowTextW")
+	procGetWindowLongW       = moduser32.NewProc("GetWindowLongW")
+	procSetWindowLongW       = moduser32.NewProc("SetWindowLongW")
+	procEnableWindow         = moduser32.NewProc("EnableWindow")
+	procLoadCursorW          = moduser32.NewProc("LoadCursorW")
+	procGetSystemMetrics     = moduser32.NewProc("GetSystemMetrics")
+	procInitCommonControlsEx = modcomctl32.NewProc("InitCommonControlsEx")
+	procGetStockObject       = syscall.NewLazyDLL("gdi32.dll").NewProc("GetStockObject")
+)
+
+const (
+	_WM_DESTROY          = 0x0002
+	_WM_CLOSE            = 0x0010
+	_WM_SETFONT          = 0x0030
+	_WM_COMMAND          = 0x0111
+	_WM_APP_CLOSE        = 0x8000 + 1 // WM_APP + 1
+	_PBM_SETPOS          = 0x0402
+	_PBM_SETRANGE32      = 0x0406
+	_PBM_SETMARQUEE      = 0x040A
+	_PBS_MARQUEE         = 0x08
+	_GWL_STYLE           = ^uintptr(15) // -16
+	_WS_CHILD            = 0x40000000
+	_WS_VISIBLE          = 0x10000000
+	_WS_CAPTION          = 0x00C00000
+	_WS_SYSMENU          = 0x00080000
+	_WS_TABSTOP          = 0x00010000
+	_WS_EX_DLGMODALFRAME = 0x00000001
+	_COLOR_BTNFACE       = 15
+	_IDC_ARROW           = 32512
+	_DEFAULT_GUIFONT     = 17
+	_ICC_PROGRESS        = 0x00000020
+
+	progressRange        = 1000
+	progressDialogWidth  = 420
+	progressDialogHeight = 160
+)
+
+// WNDCLASSEXW struct
+type _WNDCLASSEXW struct {
+	cbSize        uint32
+	style         uint32
+	lpfnWndProc   uintptr
+	cbClsExtra    int32
+	cbWndExtra    int32
+	hInstance     uintptr
+	hIcon         uintptr
+	hCursor       uintptr
+	hbrBackground uintptr
+	lpszMenuName  *uint16
+	lpszClassName *uint16
+	hIconSm       uintptr
+}
+
+// MSG struct
+type _MSG struct {
+	hwnd    uintptr
+	message uint32
+	wParam  uintptr
+	lParam  uintptr
+	time    uint32
+	ptX     int32
+	ptY     int32
+	private uint32
+}
+
+// INITCOMMONCONTROLSEX struct
+type _INITCOMMONCONTROLSEX struct {
+	dwSize uint32
+	dwICC  uint32
+}
+
+var (
+	progressClassOnce sync.Once
+	progressClassName = windows.StringToUTF16Ptr("webview2runtimeProgress")
+	progressClassErr  error
+	progressInstance  uintptr
+
+	// progressDialogs maps window handles to the dialog so the window procedure can find it.
+	progressDialogs sync.Map
+)
+
+// ProgressDialog is a native window showing the progress of a download or install, with a Cancel button.
+type ProgressDialog struct {
+	hwnd     uintptr
+	label    uintptr
+	progress uintptr
+	owner    uintptr
+	cancel   context.CancelFunc
+	closed   chan struct{}
+	once     sync.Once
+
+	lock    sync.Mutex
+	marquee bool
+}
+
+// ShowProgressDialog shows a progress window with the given title, modal to the owner window if not 0.
+// The returned context is cancelled when the user presses Cancel or closes the window.
+// The progress bar starts as a marquee. Call Close once the work has finished.
+func ShowProgressDialog(ctx context.Context, owner uintptr, title string) (*ProgressDialog, context.Context, error) {
+	progressClassOnce.Do(registerProgressClass)
+	if progressClassErr != nil {
+		return nil, ctx, progressClassErr
+	}
+	titleUTF16, err := windows.UTF16PtrFromString(title)
+	if err != nil {
+		return nil, ctx, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	dialog := &ProgressDialog{
+		owner:  owner,
+		cancel: cancel,
+		closed: make(chan struct{}),
+	}
+	created := make(chan error)
+	go dialog.run(titleUTF16, created)
+	err = <-created
+	if err != nil {
+		cancel()
+		return nil, ctx, err
+	}
+	dialog.SetMarquee()
+	return dialog, ctx, nil
+}
+
+// registerProgressClass registers the window class used by progress dialogs.
+func registerProgressClass() {
+	icc := _INITCOMMONCONTROLSEX{dwICC: _ICC_PROGRESS}
+	icc.dwSize = uint32(unsafe.Sizeof(icc))
+	procInitCommonControlsEx.Call(uintptr(unsafe.Pointer(&icc)))
+
+	var instance windows.Handle
+	err := windows.GetModuleHandleEx(0, nil, &instance)
+	if err != nil {
+		progressClassErr = err
+		return
+	}
+	progressInstance = uintptr(instance)
+
+	cursor, _, _ := procLoadCursorW.Call(0, _IDC_ARROW)
+	class := _WNDCLASSEXW{
+		lpfnWndProc:   syscall.NewCallback(progressWindowProc),
+		hInstance:     progressInstance,
+		hCursor:       cursor,
+		hbrBackground: _COLOR_BTNFACE + 1,
+		lpszClassName: progressClassName,
+	}
+	class.cbSize = uint32(unsafe.Sizeof(class))
+	ret, _, err := procRegisterClassExW.Call(uintptr(unsafe.Pointer(&class)))
+	if ret == 0 {
+		progressClassErr = fmt.Errorf("unable to register progress window class: %w", err)
+	}
+}
+
+// run creates the window and runs its message loop until the window is destroyed.
+// Windows belong to the thread that creates them, so this runs locked to its OS thread.
+func (p *ProgressDialog) run(title *uint16, created chan<- error) {
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	defer close(p.closed)
+
+	screenWidth, _, _ := procGetSystemMetrics.Call(0)  // SM_CXSCREEN
+	screenHeight, _, _ := procGetSystemMetrics.Call(1) // SM_CYSCREEN
+	hwnd, _, err := procCreateWindowExW.Call(
+		_WS_EX_DLGMODALFRAME,
+		uintptr(unsafe.Pointer(progressClassName)),
+		uintptr(unsafe.Pointer(title)),
+		_WS_CAPTION|_WS_SYSMENU|_WS_VISIBLE,
+		(screenWidth-progressDialogWidth)/2,
+		(screenHeight-progressDialogHeight)/2,
+		progressDialogWidth,
+		progressDialogHeight,
+		p.owner, 0, progressInstance, 0)
+	if hwnd == 0 {
+		created <- fmt.Errorf("unable to create progress window: %w", err)
+		return
+	}
+	p.hwnd = hwnd
+	progressDialogs.Store(hwnd, p)
+	defer progressDialogs.Delete(hwnd)
+
+	font, _, _ := procGetStockObject.Call(_DEFAULT_GUIFONT)
+	p.label = p.createChild("STATIC", "", 0, 12, 12, 380, 20, 0)
+	p.progress = p.createChild("msctls_progress32", "", 0, 12, 40, 380, 20, 0)
+	button := p.createChild("BUTTON", "Cancel", _WS_TABSTOP, 304, 76, 88, 26, uintptr(ButtonCancel))
+	for _, child := range []uintptr{p.label, button} {
+		procSendMessageW.Call(child, _WM_SETFONT, font, 1)
+	}
+	procSendMessageW.Call(p.progress, _PBM_SETRANGE32, 0, progressRange)
+
+	if p.owner != 0 {
+		procEnableWindow.Call(p.owner, 0)
+	}
+	created <- nil
+
+	var msg _MSG
+	for {
+		ret, _, _ := procGetMessageW.Call(uintptr(unsafe.Pointer(&msg)), 0, 0, 0)
+		if ret == 0 || int32(ret) == -1 {
+			break
+		}
+		procTranslateMessage.Call(uintptr(unsafe.Pointer(&msg)))
+		procDispatchMessageW.Call(uintptr(unsafe.Pointer(&msg)))
+	}
+}
+
+// createChild creates a child control of the dialog.
+func (p *ProgressDialog) createChild(class string, text string, style uintptr, x, y, width, height int, id uintptr) uintptr {
+	classUTF16, _ := windows.UTF16PtrFromString(class)
+	textUTF16, _ := windows.UTF16PtrFromString(text)
+	hwnd, _, _ := procCreateWindowExW.Call(
+		0,
+		uintptr(unsafe.Pointer(classUTF16)),
+		uintptr(unsafe.Pointer(textUTF16)),
+		_WS_CHILD|_WS_VISIBLE|style,
+		uintptr(x), uintptr(y), uintptr(width), uintptr(height),
+		p.hwnd, id, progressInstance, 0)
+	return hwnd
+}
+
+// progressWindowProc handles the messages for progress dialogs.
+func progressWindowProc(hwnd uintptr, msg uintptr, wParam uintptr, lParam uintptr) uintptr {
+	value, ok := progressDialogs.Load(hwnd)
+	if ok {
+		dialog := value.(*ProgressDialog)
+		switch msg {
+		case _WM_COMMAND:
+			if wParam&0xffff == uintptr(ButtonCancel) {
+				dialog.cancel()
+			}
+			return 0
+		case _WM_CLOSE:
+			dialog.cancel()
+			return 0
+		case _WM_APP_CLOSE:
+			if dialog.owner != 0 {
+				procEnableWindow.Call(dialog.owner, 1)
+			}
+			procDestroyWindow.Call(hwnd)
+			return 0
+		case _WM_DESTROY:
+			procPostQuitMessage.Call(0)
+			return 0
+		}
+	}
+	ret, _, _ := procDefWindowProcW.Call(hwnd, msg, wParam, lParam)
+	return ret
+}
+
+// SetStatus sets the text shown above the progress bar.
+func (p *ProgressDialog) SetStatus(status string) {
+	statusUTF16, err := windows.UTF16PtrFromString(status)
+	if err != nil {
+		return
+	}
+	procSetWindowTextW.Call(p.label, uintptr(unsafe.Pointer(statusUTF16)))
+}
+
+// SetProgress shows how much of the total has been done. If the total is not known, the bar becomes a marquee.
+func (p *ProgressDialog) SetProgress(done int64, total int64) {
+	if total <= 0 {
+		p.SetMarquee()
+		return
+	}
+	p.setMarquee(false)
+	procPostMessageW.Call(p.progress, _PBM_SETPOS, uintptr(done*progressRange/total), 0)
+}
+
+// SetMarquee shows a continuously moving bar for work whose progress is not known.
+func (p *ProgressDialog) SetMarquee() {
+	p.setMarquee(true)
+}
+
+// setMarquee switches the progress bar into or out of marquee mode.
+func (p *ProgressDialog) setMarquee(marquee bool) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if p.marquee == marquee {
+		return
+	}
+	p.marquee = marquee
+	style, _, _ := procGetWindowLongW.Call(p.progress, _GWL_STYLE)
+	if marquee {
+		style |= _PBS_MARQUEE
+	} else {
+		style &^= _PBS_MARQUEE
+	}
+	procSetWindowLongW.Call(p.progress, _GWL_STYLE, style)
+	on := uintptr(0)
+	if marquee {
+		on = 1
+	}
+	procSendMessageW.Call(p.progress, _PBM_SETMARQUEE, on, 30)
+}
+
+// Close closes the dialog and waits for its window to be destroyed. It is safe to call more than once.
+func (p *ProgressDialog) Close() {
+	p.once.Do(func() {
+		procPostMessageW.Call(p.hwnd, _WM_APP_CLOSE, 0, 0)
+		<-p.closed
+		p.cancel()
+	})
+}
+
+// reportTo returns a copy of the options that also reports download progress and phase
+// changes to the dialog, using the given messages for the status text.
+func (p *ProgressDialog) reportTo(options InstallOptions, messages Messages) InstallOptions {
+	downloadProgress := options.DownloadProgress
+	options.DownloadProgress = func(downloaded int64, total int64) {
+		p.SetProgress(downloaded, total)
+		if downloadProgress != nil {
+			downloadProgress(downloaded, total)
+		}
+	}
+	phaseChanged := options.PhaseChanged
+	options.PhaseChanged = func(phase Phase) {
+		switch phase {
+		case PhaseDownloading:
+			p.SetStatus(messages.Downloading)
+		case PhaseInstalling:
+			p.SetStatus(messages.Installing)
+			p.SetMarquee()
+		}
+		if phaseChanged != nil {
+			phaseChanged(phase)
+		}
+	}
+	return options
+}
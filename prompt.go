@@ -0,0 +1,59 @@
+package webview2runtime
+
+// Button is the button pressed to close a prompt.
+type Button int
+
+// The values match the MessageBox IDOK... return values.
+const (
+	ButtonOK     Button = 1
+	ButtonCancel Button = 2
+	ButtonAbort  Button = 3
+	ButtonRetry  Button = 4
+	ButtonIgnore Button = 5
+	ButtonYes    Button = 6
+	ButtonNo     Button = 7
+)
+
+func (b Button) String() string {
+	switch b {
+	case ButtonOK:
+		return "OK"
+	case ButtonCancel:
+		return "Cancel"
+	case ButtonAbort:
+		return "Abort"
+	case ButtonRetry:
+		return "Retry"
+	case ButtonIgnore:
+		return "Ignore"
+	case ButtonYes:
+		return "Yes"
+	case ButtonNo:
+		return "No"
+	}
+	return "Unknown"
+}
+
+// Buttons is the set of buttons shown by a prompt.
+type Buttons uint
+
+// The values match the MessageBox MB_OK... flags.
+const (
+	ButtonsOK          Buttons = 0x00000000
+	ButtonsOKCancel    Buttons = 0x00000001
+	ButtonsYesNoCancel Buttons = 0x00000003
+	ButtonsYesNo       Buttons = 0x00000004
+	ButtonsRetryCancel Buttons = 0x00000005
+)
+
+// Icon is the icon shown by a prompt.
+type Icon uint
+
+// The values match the MessageBox MB_ICON... flags.
+const (
+	IconNone        Icon = 0x00000000
+	IconError       Icon = 0x00000010
+	IconQuestion    Icon = 0x00000020
+	IconWarning     Icon = 0x00000030
+	IconInformation Icon = 0x00000040
+)
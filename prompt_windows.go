@@ -0,0 +1,27 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+// Question will prompt the user with a message and YES / NO buttons.
+// Returns true if YES is selected by the user.
+// Returns an error if something went wrong.
+func Question(caption string, title string) (bool, error) {
+	button, err := Prompt(0, caption, title, ButtonsYesNo, IconQuestion)
+	if err != nil {
+		return false, err
+	}
+	return button == ButtonYes, nil
+}
+
+// Prompt shows a message with the given buttons and icon, modal to the owner window if not 0.
+// For example, ButtonsYesNoCancel can offer "Install now", "Remind me later" and "Quit".
+// Returns the button pressed by the user.
+// Returns an error if something went wrong.
+func Prompt(owner uintptr, caption string, title string, buttons Buttons, icon Icon) (Button, error) {
+	result, err := MessageBoxEx(owner, caption, title, uint(buttons)|uint(icon))
+	if err != nil {
+		return 0, err
+	}
+	return Button(result), nil
+}
@@ -0,0 +1,122 @@
+package webview2runtime
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// clientGUID is the EdgeUpdate client ID of the webview2 runtime.
+const clientGUID = `{F3017226-FE2A-4295-8BDF-00C3A9A7E4C5}`
+
+// The EdgeUpdate keys that clients are registered under, by client ID.
+const (
+	machineClientsKey      = `HKLM\SOFTWARE\Microsoft\EdgeUpdate\Clients\`
+	machineWOW64ClientsKey = `HKLM\SOFTWARE\WOW6432Node\Microsoft\EdgeUpdate\Clients\`
+	currentUserClientsKey  = `HKCU\Software\Microsoft\EdgeUpdate\Clients\`
+)
+
+// runtimeKeys returns all the registry keys the runtime may be registered under on an operating
+// system with the given architecture, in order of preference.
+func runtimeKeys(native Arch) []string {
+	return clientKeys(clientGUID, native)
+}
+
+// clientKeys returns all the registry keys the EdgeUpdate client with the given ID may be registered
+// under on an operating system with the given architecture, in order of preference.
+// EdgeUpdate is a 32-bit application, so on 64-bit Windows (x64 and arm64) it registers clients
+// under WOW6432Node. On 32-bit Windows there is no WOW6432Node. A 64-bit native key is still checked
+// on 64-bit Windows as some installs write to it.
+func clientKeys(guid string, native Arch) []string {
+	if native == ArchX86 {
+		return []string{machineClientsKey + guid, currentUserClientsKey + guid}
+	}
+	return []string{machineWOW64ClientsKey + guid, machineClientsKey + guid, currentUserClientsKey + guid}
+}
+
+// RegistrySnapshot is a fixed set of registry values, keyed by registry key then value name.
+// Keys use the form `HKLM\SOFTWARE\...`. It implements RegistryReader and is intended for tests.
+type RegistrySnapshot map[string]map[string]string
+
+// ReadValues returns the values stored for the given key, or nil if the key is not in the snapshot.
+func (r RegistrySnapshot) ReadValues(key string) (map[string]string, error) {
+	return r[key], nil
+}
+
+// infoFromValues creates an Info from the values of an EdgeUpdate client key.
+// Returns nil if the values do not describe an installation.
+func infoFromValues(values map[string]string) *Info {
+	if values["pv"] == "" {
+		return nil
+	}
+	return &Info{
+		Location:               values["location"],
+		Name:                   values["name"],
+		Version:                values["pv"],
+		SilentUninstall:        values["SilentUninstall"],
+		InstallDate:            installDate(values),
+		RegisteredArchitecture: apArchitecture(values["ap"]),
+		Values:                 values,
+	}
+}
+
+// registeredInfo returns the installation of the EdgeUpdate client with the given ID registered
+// under the given client key, or nil if there is none. The values of the matching ClientState
+// key are included, with the values of the client key taking precedence.
+func (d *Detector) registeredInfo(key string, guid string) (*Info, error) {
+	values, err := d.readValues(key)
+	if err != nil || values["pv"] == "" {
+		return nil, err
+	}
+	state, err := d.readValues(strings.Replace(key, `\Clients\`, `\ClientState\`, 1))
+	if err != nil {
+		return nil, err
+	}
+	merged := make(map[string]string, len(values)+len(state))
+	for name, value := range state {
+		merged[name] = value
+	}
+	for name, value := range values {
+		merged[name] = value
+	}
+	info := infoFromValues(merged)
+	info.Scope = keyScope(key)
+	info.DetectionMethod = DetectionRegistry
+	info.UpdatePolicy, err = d.updatePolicy(guid)
+	if err != nil {
+		return nil, err
+	}
+	return info, nil
+}
+
+// installDate returns the install date recorded in the values of a client key, or the zero time if
+// there is none. `InstallDate` holds the date as YYYYMMDD and `DayOfInstall` as the number of days
+// since the start of 2007.
+func installDate(values map[string]string) time.Time {
+	if date, err := time.ParseInLocation("20060102", values["InstallDate"], time.Local); err == nil {
+		return date
+	}
+	if days, err := strconv.Atoi(values["DayOfInstall"]); err == nil && days > 0 {
+		return time.Date(2007, time.January, 1+days, 0, 0, 0, 0, time.Local)
+	}
+	return time.Time{}
+}
+
+// apArchitecture returns the architecture named in an EdgeUpdate `ap` value, eg `x64-stable`.
+// Returns ArchUnknown if none is named.
+func apArchitecture(ap string) Arch {
+	fields := strings.FieldsFunc(strings.ToLower(ap), func(r rune) bool {
+		return r == '-' || r == '_'
+	})
+	for _, field := range fields {
+		switch field {
+		case "x86":
+			return ArchX86
+		case "x64":
+			return ArchX64
+		case "arm64":
+			return ArchARM64
+		}
+	}
+	return ArchUnknown
+}
@@ -0,0 +1,109 @@
+package webview2runtime
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// recordingRegistry reads from a RegistrySnapshot, recording the keys read.
+type recordingRegistry struct {
+	snapshot RegistrySnapshot
+	keys     []string
+}
+
+func (r *recordingRegistry) ReadValues(key string) (map[string]string, error) {
+	r.keys = append(r.keys, key)
+	return r.snapshot.ReadValues(key)
+}
+
+func TestRuntimeKeys(t *testing.T) {
+	wow64 := []string{
+		`HKLM\SOFTWARE\WOW6432Node\Microsoft\EdgeUpdate\Clients\` + clientGUID,
+		`HKLM\SOFTWARE\Microsoft\EdgeUpdate\Clients\` + clientGUID,
+		`HKCU\Software\Microsoft\EdgeUpdate\Clients\` + clientGUID,
+	}
+	tests := []struct {
+		goarch string
+		arch   Arch
+		want   []string
+	}{
+		{"386", ArchX86, []string{
+			`HKLM\SOFTWARE\Microsoft\EdgeUpdate\Clients\` + clientGUID,
+			`HKCU\Software\Microsoft\EdgeUpdate\Clients\` + clientGUID,
+		}},
+		{"amd64", ArchX64, wow64},
+		{"arm64", ArchARM64, wow64},
+		{"riscv64", ArchUnknown, wow64},
+	}
+	for _, test := range tests {
+		t.Run(test.arch.String(), func(t *testing.T) {
+			if got := runtimeKeys(test.arch); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("runtimeKeys(%s) = %q, want %q", test.arch, got, test.want)
+			}
+			if got := runtimeKeys(goarchArchitecture(test.goarch)); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("runtimeKeys for GOARCH %s = %q, want %q", test.goarch, got, test.want)
+			}
+		})
+	}
+}
+
+func TestDetectorRegistrationsByArchitecture(t *testing.T) {
+	snapshot := RegistrySnapshot{
+		machineWOW64ClientsKey + clientGUID: {"pv": "120.0.2210.91"},
+		machineClientsKey + clientGUID:      {"pv": "119.0.2151.97"},
+		currentUserClientsKey + clientGUID:  {"pv": "118.0.2088.76"},
+	}
+	tests := []struct {
+		arch     Arch
+		versions []string
+	}{
+		{ArchX86, []string{"119.0.2151.97", "118.0.2088.76"}},
+		{ArchX64, []string{"120.0.2210.91", "119.0.2151.97", "118.0.2088.76"}},
+		{ArchARM64, []string{"120.0.2210.91", "119.0.2151.97", "118.0.2088.76"}},
+	}
+	for _, test := range tests {
+		t.Run(test.arch.String(), func(t *testing.T) {
+			registry := &recordingRegistry{snapshot: snapshot}
+			detector := &Detector{Registry: registry, Loader: noLoader, Architecture: test.arch}
+			registrations, err := detector.Registrations()
+			if err != nil {
+				t.Fatalf("Registrations() error = %v", err)
+			}
+			var versions []string
+			for _, registration := range registrations {
+				versions = append(versions, registration.Info.Version)
+			}
+			if !reflect.DeepEqual(versions, test.versions) {
+				t.Errorf("Registrations() versions = %q, want %q", versions, test.versions)
+			}
+
+			readWOW64 := false
+			for _, key := range registry.keys {
+				readWOW64 = readWOW64 || strings.Contains(key, `\WOW6432Node\`)
+			}
+			if want := test.arch != ArchX86; readWOW64 != want {
+				t.Errorf("WOW6432Node read = %t, want %t, keys read %q", readWOW64, want, registry.keys)
+			}
+		})
+	}
+}
+
+func TestAPArchitecture(t *testing.T) {
+	tests := []struct {
+		ap   string
+		want Arch
+	}{
+		{"x64-stable", ArchX64},
+		{"x86-stable", ArchX86},
+		{"ARM64-stable-statsdef_1", ArchARM64},
+		{"stable-arch_x64", ArchX64},
+		{"stable", ArchUnknown},
+		{"", ArchUnknown},
+	}
+	for _, test := range tests {
+		if got := apArchitecture(test.ap); got != test.want {
+			t.Errorf("apArchitecture(%q) = %s, want %s", test.ap, got, test.want)
+		}
+	}
+}
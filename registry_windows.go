@@ -0,0 +1,60 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"fmt"
+	"golang.org/x/sys/windows/registry"
+	"strconv"
+	"strings"
+)
+
+var registryRoots = map[string]registry.Key{
+	"HKLM": registry.LOCAL_MACHINE,
+	"HKCU": registry.CURRENT_USER,
+}
+
+// systemRegistry reads values from the Windows registry.
+// Keys are always opened in the 64-bit view so that WOW6432Node paths are read
+// verbatim regardless of the bitness of the process.
+type systemRegistry struct{}
+
+func (systemRegistry) ReadValues(key string) (map[string]string, error) {
+	parts := strings.SplitN(key, `\`, 2)
+	root, ok := registryRoots[parts[0]]
+	if !ok || len(parts) != 2 {
+		return nil, fmt.Errorf("unsupported registry key: %s", key)
+	}
+	k, err := registry.OpenKey(root, parts[1], registry.QUERY_VALUE|registry.WOW64_64KEY)
+	if err == registry.ErrNotExist {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer k.Close()
+
+	names, err := k.ReadValueNames(-1)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string]string, len(names))
+	for _, name := range names {
+		value, _, err := k.GetStringValue(name)
+		if err == nil {
+			values[name] = value
+			continue
+		}
+		number, _, err := k.GetIntegerValue(name)
+		if err == nil {
+			values[name] = strconv.FormatUint(number, 10)
+			continue
+		}
+		multi, _, err := k.GetStringsValue(name)
+		if err == nil {
+			values[name] = strings.Join(multi, "\n")
+		}
+	}
+	return values, nil
+}
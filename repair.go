@@ -0,0 +1,20 @@
+package webview2runtime
+
+// RepairOptions are the options used by RepairInstallation.
+type RepairOptions struct {
+	// InstallOptions are used to run the installer.
+	InstallOptions
+
+	// StandaloneInstaller, if set, is the path of the standalone installer to run instead of
+	// downloading the bootstrapper.
+	StandaloneInstaller string
+
+	// Reinstall uninstalls the runtime and installs it again if the installer reports that the
+	// runtime is already installed and so does nothing. The runtime can only be reinstalled if an
+	// uninstall command is registered, which is not the case for the runtime included in Windows 11.
+	Reinstall bool
+
+	// InUse determines what happens if apps are using the runtime before it is repaired.
+	// By default the installer is run regardless.
+	InUse InUseOptions
+}
@@ -0,0 +1,57 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+)
+
+// RepairInstallation runs the installer even when a version of the runtime is already registered,
+// eg when ValidateInstallation reports problems or users report blank windows. Installs that
+// finished while waiting for the install mutex do not stop the installer being run. The options may be nil.
+// Returns the result of the last install run. With DryRun set, apps using the runtime are left alone
+// and the result holds the Plan of the install.
+func RepairInstallation(ctx context.Context, options *RepairOptions) (*InstallResult, error) {
+	if options == nil {
+		options = &RepairOptions{}
+	}
+	installOptions := options.InstallOptions
+	installOptions.ReinstallAfterWait = true
+	if installOptions.DryRun {
+		return options.run(ctx, &installOptions)
+	}
+
+	info, err := GetInstallation()
+	if err == nil && info != nil {
+		err = info.WaitForIdle(ctx, options.InUse)
+	}
+	if err != nil {
+		return &InstallResult{Error: err}, err
+	}
+
+	result, err := options.run(ctx, &installOptions)
+	if err != nil || result.Reason != ReasonAlreadyInstalled || !options.Reinstall {
+		return result, err
+	}
+
+	info, err = GetInstallation()
+	if err != nil || info == nil || info.SilentUninstall == "" {
+		return result, err
+	}
+	err = info.Uninstall(ctx)
+	if err != nil {
+		result.Success = false
+		result.Error = err
+		return result, err
+	}
+	return options.run(ctx, &installOptions)
+}
+
+// run runs the standalone installer if one is given, otherwise it downloads and runs the bootstrapper.
+func (o *RepairOptions) run(ctx context.Context, installOptions *InstallOptions) (*InstallResult, error) {
+	if o.StandaloneInstaller != "" {
+		return InstallUsingStandaloneInstallerWithContext(ctx, o.StandaloneInstaller, installOptions)
+	}
+	return InstallUsingBootstrapperWithResult(ctx, installOptions)
+}
@@ -0,0 +1,20 @@
+package webview2runtime
+
+import (
+	"context"
+)
+
+// ProcessRunner runs the installer and the other programs this package starts, eg the uninstaller
+// and MicrosoftEdgeUpdate.exe. Tests may provide one that simulates installer exit codes.
+type ProcessRunner interface {
+	// Run runs the program with the given arguments, waits for it to exit and returns its exit code.
+	// The program should be killed if the context is cancelled.
+	Run(ctx context.Context, program string, args []string) (uint32, error)
+}
+
+// Downloader downloads the bootstrapper. Tests may provide one that writes a fake installer.
+type Downloader interface {
+	// Download downloads the url to the given path.
+	// The error should wrap ErrDownloadFailed if the url could not be downloaded.
+	Download(ctx context.Context, url string, path string) error
+}
@@ -0,0 +1,28 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+	"net/http"
+)
+
+// systemProcessRunner runs programs using exec.Cmd or ShellExecuteEx according to the options.
+type systemProcessRunner struct {
+	options *InstallOptions
+}
+
+func (r systemProcessRunner) Run(ctx context.Context, program string, args []string) (uint32, error) {
+	return startProcess(ctx, program, args, r.options)
+}
+
+// httpDownloader downloads using HTTP, retrying according to the options.
+type httpDownloader struct {
+	client  *http.Client
+	options *InstallOptions
+}
+
+func (d httpDownloader) Download(ctx context.Context, url string, path string) error {
+	return downloadWithRetry(ctx, d.client, url, path, d.options)
+}
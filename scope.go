@@ -0,0 +1,72 @@
+package webview2runtime
+
+import (
+	"strings"
+)
+
+// Scope describes where a runtime comes from.
+type Scope int
+
+const (
+	// ScopeNone means no runtime was found.
+	ScopeNone Scope = iota
+	// ScopeMachine is an evergreen runtime installed for all users.
+	ScopeMachine
+	// ScopeUser is an evergreen runtime installed for the current user.
+	ScopeUser
+	// ScopeFixedVersion is a fixed version runtime selected by the BrowserExecutableFolder policy.
+	ScopeFixedVersion
+	// ScopeEnvironment is a runtime selected by the WEBVIEW2_BROWSER_EXECUTABLE_FOLDER environment variable.
+	ScopeEnvironment
+)
+
+// String returns the name of the scope.
+func (s Scope) String() string {
+	switch s {
+	case ScopeMachine:
+		return "machine"
+	case ScopeUser:
+		return "user"
+	case ScopeFixedVersion:
+		return "fixed-version"
+	case ScopeEnvironment:
+		return "environment"
+	}
+	return "none"
+}
+
+// keyScope returns the scope of a registry key.
+func keyScope(key string) Scope {
+	if strings.HasPrefix(key, `HKCU\`) {
+		return ScopeUser
+	}
+	return ScopeMachine
+}
+
+// InstallScope determines whether the runtime is installed for all users or only the current user.
+type InstallScope int
+
+const (
+	// InstallScopeAuto installs per-machine, falling back to a per-user install when elevation is
+	// unavailable: the user declines the UAC prompt, or the Elevation option is ElevationNever and the
+	// process is not elevated. This is the default.
+	InstallScopeAuto InstallScope = iota
+	// InstallScopeMachine installs the runtime for all users, which needs administrator rights.
+	InstallScopeMachine
+	// InstallScopeUser installs the runtime for the current user without elevating, which is the only
+	// option on locked-down machines. It cannot be used from an elevated process.
+	InstallScopeUser
+)
+
+// String returns the name of the install scope.
+func (s InstallScope) String() string {
+	switch s {
+	case InstallScopeAuto:
+		return "auto"
+	case InstallScopeMachine:
+		return "machine"
+	case InstallScopeUser:
+		return "user"
+	}
+	return "unknown"
+}
@@ -0,0 +1,50 @@
+package webview2runtime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sdkBranches are the build numbers that each major version of the runtime branched at, in order.
+// A WebView2 SDK release `1.0.<build>.<patch>` needs runtime `<major>.0.<build>.<patch>` or newer,
+// where major is the first branch at or after the build. Prerelease SDKs, `1.0.<build>-prerelease`,
+// need a preview channel build of the next major version.
+var sdkBranches = []struct {
+	major int
+	build int
+}{
+	{86, 622}, {87, 664}, {88, 705}, {89, 774}, {90, 818}, {91, 864}, {92, 902}, {93, 961},
+	{94, 992}, {95, 1020}, {96, 1054}, {97, 1072}, {98, 1108}, {99, 1150}, {100, 1185}, {101, 1210},
+	{102, 1245}, {103, 1264}, {104, 1293}, {105, 1343}, {106, 1370}, {107, 1418}, {108, 1462}, {109, 1518},
+	{110, 1587}, {111, 1661}, {112, 1722}, {113, 1774}, {114, 1823}, {115, 1901}, {116, 1938}, {117, 2045},
+	{118, 2088}, {119, 2151}, {120, 2210}, {121, 2277}, {122, 2365}, {123, 2420}, {124, 2478},
+}
+
+// MinimumRuntimeForSDK returns the oldest runtime version that apps built with the given WebView2 SDK
+// version, eg `1.0.1518.46` or `1.0.1549-prerelease`, can use.
+// Returns an error wrapping ErrUnknownSDKVersion if the SDK version is invalid or newer than this package knows about.
+func MinimumRuntimeForSDK(sdkVersion string) (string, error) {
+	version := strings.TrimSuffix(strings.TrimSpace(sdkVersion), "-prerelease")
+	parts := strings.Split(version, ".")
+	if len(parts) < 3 || len(parts) > 4 || parts[0] != "1" || parts[1] != "0" {
+		return "", fmt.Errorf("%w: %s", ErrUnknownSDKVersion, sdkVersion)
+	}
+	build, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrUnknownSDKVersion, sdkVersion)
+	}
+	patch := 0
+	if len(parts) == 4 {
+		patch, err = strconv.Atoi(parts[3])
+		if err != nil {
+			return "", fmt.Errorf("%w: %s", ErrUnknownSDKVersion, sdkVersion)
+		}
+	}
+	for _, branch := range sdkBranches {
+		if build <= branch.build {
+			return fmt.Sprintf("%d.0.%d.%d", branch.major, build, patch), nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s is newer than the versions known to this package", ErrUnknownSDKVersion, sdkVersion)
+}
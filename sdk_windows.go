@@ -0,0 +1,24 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+)
+
+// EnsureCompatibleWith is the same as EnsureInstalled but installs or upgrades the runtime to at least
+// the version needed by apps built with the given WebView2 SDK version. See MinimumRuntimeForSDK.
+func EnsureCompatibleWith(sdkVersion string, opts ...Option) error {
+	return EnsureCompatibleWithContext(context.Background(), sdkVersion, opts...)
+}
+
+// EnsureCompatibleWithContext is the same as EnsureCompatibleWith but the install is aborted if the context
+// is cancelled or times out.
+func EnsureCompatibleWithContext(ctx context.Context, sdkVersion string, opts ...Option) error {
+	minVersion, err := MinimumRuntimeForSDK(sdkVersion)
+	if err != nil {
+		return &EnsureError{Stage: StageDetect, Err: err}
+	}
+	return EnsureInstalledWithContext(ctx, minVersion, opts...)
+}
@@ -0,0 +1,19 @@
+package webview2runtime
+
+// SignatureInfo contains the details of the Authenticode signature of a file.
+type SignatureInfo struct {
+	// Signer is the subject name of the signing certificate, eg "Microsoft Corporation".
+	Signer string
+	// Issuer is the name of the certificate authority that issued the signing certificate.
+	Issuer string
+	// Trusted is true if WinVerifyTrust reports the signature as valid and trusted.
+	Trusted bool
+	// MicrosoftRoot is true if the signing certificate chains to a Microsoft root certificate.
+	MicrosoftRoot bool
+}
+
+// IsMicrosoft returns true if the file was signed by Microsoft, ie the signing certificate chains to
+// a Microsoft root. The name of the signer is not used, as anyone can get a certificate with that name.
+func (s SignatureInfo) IsMicrosoft() bool {
+	return s.MicrosoftRoot
+}
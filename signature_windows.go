@@ -0,0 +1,175 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"fmt"
+	"golang.org/x/sys/windows"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	modcrypt32           = syscall.NewLazyDLL("crypt32.dll")
+	procCryptMsgGetParam = modcrypt32.NewProc("CryptMsgGetParam")
+	procCryptMsgClose    = modcrypt32.NewProc("CryptMsgClose")
+)
+
+const (
+	_CMSG_SIGNER_INFO_PARAM = 6
+	encodingTypes           = windows.X509_ASN_ENCODING | windows.PKCS_7_ASN_ENCODING
+
+	// Accept the Microsoft application root, which signs the runtime, as well as the product roots
+	_MICROSOFT_ROOT_CERT_CHAIN_POLICY_CHECK_APPLICATION_ROOT_FLAG = 0x00020000
+)
+
+// CMSG_SIGNER_INFO struct. Only the fields needed to find the signing certificate are declared.
+type _CMSG_SIGNER_INFO struct {
+	dwVersion    uint32
+	Issuer       windows.CertNameBlob
+	SerialNumber windows.CryptIntegerBlob
+}
+
+// VerifySignature checks the Authenticode signature of the given file, eg a bootstrapper or
+// standalone installer. The details of the signature are returned when they can be read.
+// Returns an error if the file is unsigned, the signature is not trusted or the signer is not Microsoft.
+func VerifySignature(path string) (SignatureInfo, error) {
+	var info SignatureInfo
+	trustErr := verifyTrust(path)
+	info.Trusted = trustErr == nil
+
+	err := readSigner(path, &info)
+	if err != nil {
+		if trustErr != nil {
+			return info, trustErr
+		}
+		return info, err
+	}
+
+	if trustErr != nil {
+		return info, trustErr
+	}
+	if !info.IsMicrosoft() {
+		return info, fmt.Errorf("%s is signed by %q, whose certificate does not chain to a Microsoft root", path, info.Signer)
+	}
+	return info, nil
+}
+
+// verifyTrust checks the Authenticode signature of the given file using WinVerifyTrust.
+// Revocation is checked for the whole chain. Returns nil if the signature is valid and trusted.
+func verifyTrust(path string) error {
+	pathUTF16, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+	fileInfo := &windows.WinTrustFileInfo{
+		Size:     uint32(unsafe.Sizeof(windows.WinTrustFileInfo{})),
+		FilePath: pathUTF16,
+	}
+	data := &windows.WinTrustData{
+		Size:                            uint32(unsafe.Sizeof(windows.WinTrustData{})),
+		UIChoice:                        windows.WTD_UI_NONE,
+		RevocationChecks:                windows.WTD_REVOKE_WHOLECHAIN,
+		UnionChoice:                     windows.WTD_CHOICE_FILE,
+		StateAction:                     windows.WTD_STATEACTION_VERIFY,
+		FileOrCatalogOrBlobOrSgnrOrCert: unsafe.Pointer(fileInfo),
+	}
+	trustErr := windows.WinVerifyTrustEx(windows.InvalidHWND, &windows.WINTRUST_ACTION_GENERIC_VERIFY_V2, data)
+
+	// Release the state data allocated by the verify action
+	data.StateAction = windows.WTD_STATEACTION_CLOSE
+	_ = windows.WinVerifyTrustEx(windows.InvalidHWND, &windows.WINTRUST_ACTION_GENERIC_VERIFY_V2, data)
+
+	if trustErr != nil {
+		return fmt.Errorf("invalid signature on %s: %w", path, trustErr)
+	}
+	return nil
+}
+
+// readSigner reads the subject and issuer names of the certificate used to sign the given file,
+// and whether it chains to a Microsoft root, into info.
+func readSigner(path string, info *SignatureInfo) error {
+	pathUTF16, err := windows.UTF16PtrFromString(path)
+	if err != nil {
+		return err
+	}
+	var store, msg windows.Handle
+	err = windows.CryptQueryObject(
+		windows.CERT_QUERY_OBJECT_FILE,
+		unsafe.Pointer(pathUTF16),
+		windows.CERT_QUERY_CONTENT_FLAG_PKCS7_SIGNED_EMBED,
+		windows.CERT_QUERY_FORMAT_FLAG_BINARY,
+		0, nil, nil, nil, &store, &msg, nil)
+	if err != nil {
+		return fmt.Errorf("unable to read signature from %s: %w", path, err)
+	}
+	defer windows.CertCloseStore(store, 0)
+	defer procCryptMsgClose.Call(uintptr(msg))
+
+	// Find the signer so the signing certificate can be picked out of the store
+	var size uint32
+	ret, _, err := procCryptMsgGetParam.Call(uintptr(msg), _CMSG_SIGNER_INFO_PARAM, 0, 0, uintptr(unsafe.Pointer(&size)))
+	if ret == 0 {
+		return fmt.Errorf("unable to read signer from %s: %w", path, err)
+	}
+	buffer := make([]byte, size)
+	ret, _, err = procCryptMsgGetParam.Call(uintptr(msg), _CMSG_SIGNER_INFO_PARAM, 0, uintptr(unsafe.Pointer(&buffer[0])), uintptr(unsafe.Pointer(&size)))
+	if ret == 0 {
+		return fmt.Errorf("unable to read signer from %s: %w", path, err)
+	}
+	signerInfo := (*_CMSG_SIGNER_INFO)(unsafe.Pointer(&buffer[0]))
+
+	certInfo := windows.CertInfo{
+		Issuer:       signerInfo.Issuer,
+		SerialNumber: signerInfo.SerialNumber,
+	}
+	cert, err := windows.CertFindCertificateInStore(store, encodingTypes, 0, windows.CERT_FIND_SUBJECT_CERT, unsafe.Pointer(&certInfo), nil)
+	if err != nil {
+		return fmt.Errorf("unable to find signing certificate in %s: %w", path, err)
+	}
+	defer windows.CertFreeCertificateContext(cert)
+
+	info.Signer = certName(cert, 0)
+	info.Issuer = certName(cert, windows.CERT_NAME_ISSUER_FLAG)
+	info.MicrosoftRoot, err = chainsToMicrosoftRoot(cert, store)
+	if err != nil {
+		return fmt.Errorf("unable to check the certificate chain of %s: %w", path, err)
+	}
+	return nil
+}
+
+// chainsToMicrosoftRoot returns true if the certificate chains to a Microsoft root certificate.
+// The store holds the intermediate certificates included in the signature.
+func chainsToMicrosoftRoot(cert *windows.CertContext, store windows.Handle) (bool, error) {
+	para := &windows.CertChainPara{Size: uint32(unsafe.Sizeof(windows.CertChainPara{}))}
+	var chain *windows.CertChainContext
+	err := windows.CertGetCertificateChain(0, cert, nil, store, para, 0, 0, &chain)
+	if err != nil {
+		return false, err
+	}
+	defer windows.CertFreeCertificateChain(chain)
+
+	policy := &windows.CertChainPolicyPara{
+		Size:  uint32(unsafe.Sizeof(windows.CertChainPolicyPara{})),
+		Flags: _MICROSOFT_ROOT_CERT_CHAIN_POLICY_CHECK_APPLICATION_ROOT_FLAG,
+	}
+	status := &windows.CertChainPolicyStatus{Size: uint32(unsafe.Sizeof(windows.CertChainPolicyStatus{}))}
+	err = windows.CertVerifyCertificateChainPolicy(windows.CERT_CHAIN_POLICY_MICROSOFT_ROOT, chain, policy, status)
+	if err != nil {
+		return false, err
+	}
+	return status.Error == 0, nil
+}
+
+// certName returns the simple display name of the certificate subject, or issuer if flags
+// contains CERT_NAME_ISSUER_FLAG.
+func certName(cert *windows.CertContext, flags uint32) string {
+	size := windows.CertGetNameString(cert, windows.CERT_NAME_SIMPLE_DISPLAY_TYPE, flags, nil, nil, 0)
+	if size <= 1 {
+		return ""
+	}
+	name := make([]uint16, size)
+	windows.CertGetNameString(cert, windows.CERT_NAME_SIMPLE_DISPLAY_TYPE, flags, nil, &name[0], size)
+	return windows.UTF16ToString(name)
+}
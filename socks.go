@@ -0,0 +1,193 @@
+package webview2runtime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// socks5Dialer dials connections through a SOCKS5 proxy (RFC 1928).
+// Username/password authentication (RFC 1929) is used when credentials are given.
+type socks5Dialer struct {
+	proxyAddress string
+	username     string
+	password     string
+	dialer       net.Dialer
+}
+
+// newSOCKS5Dialer parses a proxy address of the form `host:port` or `socks5://[user:pass@]host:port`.
+func newSOCKS5Dialer(proxy string) (*socks5Dialer, error) {
+	if !hasScheme(proxy) {
+		proxy = "socks5://" + proxy
+	}
+	u, err := url.Parse(proxy)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SOCKS5 proxy: %w", err)
+	}
+	if u.Scheme != "socks5" && u.Scheme != "socks5h" {
+		return nil, fmt.Errorf("invalid SOCKS5 proxy scheme: %s", u.Scheme)
+	}
+	if u.Port() == "" {
+		return nil, fmt.Errorf("invalid SOCKS5 proxy: no port given in %s", proxy)
+	}
+	result := &socks5Dialer{proxyAddress: u.Host}
+	if u.User != nil {
+		result.username = u.User.Username()
+		result.password, _ = u.User.Password()
+	}
+	return result, nil
+}
+
+func hasScheme(address string) bool {
+	u, err := url.Parse(address)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
+
+// DialContext connects to the address via the proxy.
+func (d *socks5Dialer) DialContext(ctx context.Context, network string, address string) (net.Conn, error) {
+	if network != "tcp" && network != "tcp4" && network != "tcp6" {
+		return nil, fmt.Errorf("SOCKS5 proxy does not support network %s", network)
+	}
+	conn, err := d.dialer.DialContext(ctx, "tcp", d.proxyAddress)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to SOCKS5 proxy %s: %w", d.proxyAddress, err)
+	}
+	// A proxy that stalls during the handshake must not outlive the context
+	deadline, hasDeadline := ctx.Deadline()
+	if hasDeadline {
+		conn.SetDeadline(deadline)
+	}
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			conn.Close()
+		case <-done:
+		}
+	}()
+	err = d.handshake(conn, address)
+	close(done)
+	<-stopped
+	switch {
+	case ctx.Err() != nil:
+		// The connection was closed by the context, so report why
+		err = ctx.Err()
+	case err != nil && hasDeadline && !time.Now().Before(deadline):
+		// The connection timed out at the deadline of the context before the context noticed
+		err = context.DeadlineExceeded
+	}
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("SOCKS5 proxy %s: %w", d.proxyAddress, err)
+	}
+	conn.SetDeadline(time.Time{})
+	return conn, nil
+}
+
+func (d *socks5Dialer) handshake(conn io.ReadWriter, address string) error {
+	host, portString, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	port, err := strconv.Atoi(portString)
+	if err != nil {
+		return fmt.Errorf("invalid port: %s", portString)
+	}
+	if len(host) > 255 {
+		return fmt.Errorf("host name too long: %s", host)
+	}
+
+	// Negotiate the authentication method
+	method := byte(0x00) // No authentication
+	if d.username != "" {
+		method = 0x02 // Username/password
+	}
+	_, err = conn.Write([]byte{0x05, 0x01, method})
+	if err != nil {
+		return err
+	}
+	reply := make([]byte, 2)
+	_, err = io.ReadFull(conn, reply)
+	if err != nil {
+		return err
+	}
+	if reply[0] != 0x05 {
+		return fmt.Errorf("unexpected protocol version %d", reply[0])
+	}
+	if reply[1] != method {
+		return errors.New("no acceptable authentication method")
+	}
+	if method == 0x02 {
+		err = d.authenticate(conn)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Request a connection, letting the proxy resolve the host name
+	request := []byte{0x05, 0x01, 0x00, 0x03, byte(len(host))}
+	request = append(request, host...)
+	request = append(request, byte(port>>8), byte(port))
+	_, err = conn.Write(request)
+	if err != nil {
+		return err
+	}
+	header := make([]byte, 4)
+	_, err = io.ReadFull(conn, header)
+	if err != nil {
+		return err
+	}
+	if header[1] != 0x00 {
+		return fmt.Errorf("connection to %s refused with code %d", address, header[1])
+	}
+
+	// Discard the bound address
+	var addressLength int
+	switch header[3] {
+	case 0x01:
+		addressLength = net.IPv4len
+	case 0x04:
+		addressLength = net.IPv6len
+	case 0x03:
+		length := make([]byte, 1)
+		_, err = io.ReadFull(conn, length)
+		if err != nil {
+			return err
+		}
+		addressLength = int(length[0])
+	default:
+		return fmt.Errorf("unexpected address type %d", header[3])
+	}
+	_, err = io.ReadFull(conn, make([]byte, addressLength+2))
+	return err
+}
+
+func (d *socks5Dialer) authenticate(conn io.ReadWriter) error {
+	if len(d.username) > 255 || len(d.password) > 255 {
+		return errors.New("username or password too long")
+	}
+	request := []byte{0x01, byte(len(d.username))}
+	request = append(request, d.username...)
+	request = append(request, byte(len(d.password)))
+	request = append(request, d.password...)
+	_, err := conn.Write(request)
+	if err != nil {
+		return err
+	}
+	reply := make([]byte, 2)
+	_, err = io.ReadFull(conn, reply)
+	if err != nil {
+		return err
+	}
+	if reply[1] != 0x00 {
+		return errors.New("authentication failed")
+	}
+	return nil
+}
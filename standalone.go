@@ -0,0 +1,16 @@
+package webview2runtime
+
+// StandaloneInstallerFilename returns the filename Microsoft uses for the evergreen standalone
+// installer for the given architecture, eg `MicrosoftEdgeWebView2RuntimeInstallerX64.exe`.
+// Returns a blank string for ArchUnknown.
+func StandaloneInstallerFilename(arch Arch) string {
+	switch arch {
+	case ArchX86:
+		return "MicrosoftEdgeWebView2RuntimeInstallerX86.exe"
+	case ArchX64:
+		return "MicrosoftEdgeWebView2RuntimeInstallerX64.exe"
+	case ArchARM64:
+		return "MicrosoftEdgeWebView2RuntimeInstallerARM64.exe"
+	}
+	return ""
+}
@@ -0,0 +1,50 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+)
+
+// StandaloneInstallerFilenameForSystem returns the standalone installer filename for the architecture
+// of this operating system.
+func StandaloneInstallerFilenameForSystem() string {
+	return StandaloneInstallerFilename(nativeArchitecture())
+}
+
+// InstallUsingStandaloneInstaller runs the offline evergreen standalone installer at the given path
+// with `/silent /install`. No network access is needed. The installer file is never removed.
+// Returns the result of the install, and an error if it failed.
+func InstallUsingStandaloneInstaller(path string) (*InstallResult, error) {
+	return InstallUsingStandaloneInstallerWithContext(context.Background(), path, nil)
+}
+
+// InstallUsingStandaloneInstallerWithContext is the same as InstallUsingStandaloneInstaller but runs the
+// installer using the given options. The installer is killed if the context is cancelled or times out.
+// The options may be nil.
+func InstallUsingStandaloneInstallerWithContext(ctx context.Context, path string, options *InstallOptions) (*InstallResult, error) {
+	return Install(ctx, UsingOptions(options), FromStandaloneInstaller(path))
+}
+
+// installStandalone runs the standalone installer at the given path.
+func installStandalone(ctx context.Context, path string, options *InstallOptions) (*InstallResult, error) {
+	err := options.validate()
+	if err != nil {
+		return &InstallResult{Installer: path, Error: err}, err
+	}
+	if options.dryRun() {
+		return planInstall(sourceStandalone, path, options.withSilent())
+	}
+	release, skipped, err := acquireInstallLockAndRecheck(ctx, options)
+	if err != nil {
+		return &InstallResult{Installer: path, Error: err}, err
+	}
+	defer release()
+	if skipped != nil {
+		skipped.Installer = path
+		return skipped, nil
+	}
+
+	return install(ctx, installerRun{path: path}, options.withSilent())
+}
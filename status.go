@@ -0,0 +1,35 @@
+package webview2runtime
+
+// RuntimeStatus describes the installed runtime relative to a required version.
+type RuntimeStatus int
+
+const (
+	// StatusNotInstalled means no runtime is installed.
+	StatusNotInstalled RuntimeStatus = iota
+	// StatusInstalledTooOld means the installed runtime is older than the required version.
+	StatusInstalledTooOld
+	// StatusInstalledOK means the installed runtime is the required version.
+	StatusInstalledOK
+	// StatusInstalledNewer means the installed runtime is newer than the required version.
+	StatusInstalledNewer
+	// StatusNotApplicable means the runtime does not exist on this platform. It is only returned
+	// on platforms other than Windows.
+	StatusNotApplicable
+)
+
+// String returns the name of the status.
+func (s RuntimeStatus) String() string {
+	switch s {
+	case StatusNotInstalled:
+		return "not-installed"
+	case StatusInstalledTooOld:
+		return "too-old"
+	case StatusInstalledOK:
+		return "ok"
+	case StatusInstalledNewer:
+		return "newer"
+	case StatusNotApplicable:
+		return "not-applicable"
+	}
+	return "unknown"
+}
@@ -0,0 +1,61 @@
+package webview2runtime
+
+// WebView2Page is the Microsoft page describing the WebView2 runtime.
+const WebView2Page = "https://developer.microsoft.com/en-us/microsoft-edge/webview2/"
+
+// Button IDs returned by TaskDialog for the standard buttons.
+const (
+	DialogOK     = 1 // IDOK
+	DialogCancel = 2 // IDCANCEL
+)
+
+// TaskDialogIcon is the icon shown in a task dialog.
+type TaskDialogIcon int
+
+const (
+	TaskDialogIconNone TaskDialogIcon = iota
+	TaskDialogIconInformation
+	TaskDialogIconWarning
+	TaskDialogIconError
+	TaskDialogIconShield
+)
+
+// TaskDialogButton is a custom button shown in a task dialog.
+type TaskDialogButton struct {
+	// ID is returned by TaskDialog when the button is pressed. Avoid the standard IDs such as DialogCancel.
+	ID int
+	// Text is the label of the button, eg "Download (≈2 MB)".
+	Text string
+}
+
+// TaskDialogConfig describes a task dialog.
+type TaskDialogConfig struct {
+	// Owner is the window the dialog is modal to. Defaults to no owner.
+	Owner           uintptr
+	Title           string
+	MainInstruction string
+	Content         string
+	Icon            TaskDialogIcon
+	// Buttons are shown in place of the standard OK button. A Cancel button is always shown.
+	Buttons []TaskDialogButton
+	// DefaultButton is the ID of the button selected by default.
+	DefaultButton int
+	// ExpandedInformation is extra detail hidden until the user expands the dialog.
+	ExpandedInformation string
+	// Footer is shown at the bottom of the dialog. It may contain `<a href="...">` hyperlinks,
+	// which are opened in the browser.
+	Footer string
+	// LearnMore adds a hyperlink to the WebView2 page in the footer.
+	LearnMore bool
+}
+
+func (c *TaskDialogConfig) footer() string {
+	if !c.LearnMore {
+		return c.Footer
+	}
+	link := `<a href="` + WebView2Page + `">Learn more about WebView2</a>`
+	if c.Footer == "" {
+		return link
+	}
+	return c.Footer + "\n" + link
+}
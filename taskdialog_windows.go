@@ -0,0 +1,185 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"encoding/binary"
+	"golang.org/x/sys/windows"
+	"runtime"
+	"strings"
+	"syscall"
+	"unsafe"
+)
+
+var (
+	modcomctl32            = syscall.NewLazyDLL("comctl32.dll")
+	procTaskDialogIndirect = modcomctl32.NewProc("TaskDialogIndirect")
+)
+
+const (
+	_TDF_ENABLE_HYPERLINKS         = 0x0001
+	_TDF_ALLOW_DIALOG_CANCELLATION = 0x0008
+	_TDCBF_OK_BUTTON               = 0x0001
+	_TDCBF_CANCEL_BUTTON           = 0x0008
+	_TDN_HYPERLINK_CLICKED         = 3
+)
+
+// resource returns the MAKEINTRESOURCE value of the stock icon.
+func (i TaskDialogIcon) resource() uintptr {
+	switch i {
+	case TaskDialogIconWarning:
+		return 0xFFFF // TD_WARNING_ICON
+	case TaskDialogIconError:
+		return 0xFFFE // TD_ERROR_ICON
+	case TaskDialogIconInformation:
+		return 0xFFFD // TD_INFORMATION_ICON
+	case TaskDialogIconShield:
+		return 0xFFFC // TD_SHIELD_ICON
+	}
+	return 0
+}
+
+// messageBoxFlags returns the MessageBox icon flag equivalent to the icon.
+func (i TaskDialogIcon) messageBoxFlags() uint {
+	switch i {
+	case TaskDialogIconWarning, TaskDialogIconShield:
+		return 0x00000030 // MB_ICONWARNING
+	case TaskDialogIconError:
+		return 0x00000010 // MB_ICONERROR
+	case TaskDialogIconInformation:
+		return 0x00000040 // MB_ICONINFORMATION
+	}
+	return 0
+}
+
+// taskDialogCallback opens hyperlinks clicked in a task dialog.
+var taskDialogCallback = syscall.NewCallback(func(hwnd uintptr, msg uintptr, wParam uintptr, lParam *uint16, data uintptr) uintptr {
+	if msg == _TDN_HYPERLINK_CLICKED && lParam != nil {
+		verb, _ := windows.UTF16PtrFromString("open")
+		_ = windows.ShellExecute(0, verb, lParam, nil, nil, windows.SW_SHOWNORMAL)
+	}
+	return 0 // S_OK
+})
+
+// TaskDialog shows a task dialog and returns the ID of the button pressed, or DialogCancel if
+// the dialog was cancelled. TaskDialogIndirect needs version 6 of the common controls, which
+// requires the application to have a manifest. Otherwise a MessageBox is shown instead, where
+// OK selects the first of the Buttons.
+// Returns an error if something went wrong.
+func TaskDialog(config TaskDialogConfig) (int, error) {
+	if procTaskDialogIndirect.Find() != nil {
+		return taskDialogFallback(config)
+	}
+
+	// The strings are kept in strs so they stay alive until the dialog has closed
+	var strs [][]uint16
+	var strErr error
+	str := func(s string) uintptr {
+		if s == "" {
+			return 0
+		}
+		u, err := windows.UTF16FromString(s)
+		if err != nil {
+			strErr = err
+			return 0
+		}
+		strs = append(strs, u)
+		return uintptr(unsafe.Pointer(&u[0]))
+	}
+
+	// TASKDIALOG_BUTTON and TASKDIALOGCONFIG are byte packed so are built by hand
+	var buttons packedStruct
+	for _, button := range config.Buttons {
+		buttons.uint32(uint32(button.ID))
+		buttons.pointer(str(button.Text))
+	}
+	var buttonsPtr uintptr
+	if len(buttons) > 0 {
+		buttonsPtr = uintptr(unsafe.Pointer(&buttons[0]))
+	}
+	commonButtons := uint32(_TDCBF_CANCEL_BUTTON)
+	if len(config.Buttons) == 0 {
+		commonButtons |= _TDCBF_OK_BUTTON
+	}
+
+	var dialog packedStruct
+	dialog.uint32(0) // cbSize, set below
+	dialog.pointer(config.Owner)
+	dialog.pointer(0) // hInstance
+	dialog.uint32(_TDF_ENABLE_HYPERLINKS | _TDF_ALLOW_DIALOG_CANCELLATION)
+	dialog.uint32(commonButtons)
+	dialog.pointer(str(config.Title))
+	dialog.pointer(config.Icon.resource())
+	dialog.pointer(str(config.MainInstruction))
+	dialog.pointer(str(config.Content))
+	dialog.uint32(uint32(len(config.Buttons)))
+	dialog.pointer(buttonsPtr)
+	dialog.uint32(uint32(config.DefaultButton))
+	dialog.uint32(0)  // cRadioButtons
+	dialog.pointer(0) // pRadioButtons
+	dialog.uint32(0)  // nDefaultRadioButton
+	dialog.pointer(0) // pszVerificationText
+	dialog.pointer(str(config.ExpandedInformation))
+	dialog.pointer(0) // pszExpandedControlText
+	dialog.pointer(0) // pszCollapsedControlText
+	dialog.pointer(0) // pszFooterIcon
+	dialog.pointer(str(config.footer()))
+	dialog.pointer(taskDialogCallback)
+	dialog.pointer(0) // lpCallbackData
+	dialog.uint32(0)  // cxWidth
+	binary.LittleEndian.PutUint32(dialog, uint32(len(dialog)))
+	if strErr != nil {
+		return -1, strErr
+	}
+
+	var pressed int32
+	hr, _, _ := procTaskDialogIndirect.Call(uintptr(unsafe.Pointer(&dialog[0])), uintptr(unsafe.Pointer(&pressed)), 0, 0)
+	runtime.KeepAlive(strs)
+	runtime.KeepAlive(buttons)
+	if int32(hr) < 0 {
+		return -1, syscall.Errno(hr)
+	}
+	return int(pressed), nil
+}
+
+// taskDialogFallback shows the task dialog as a MessageBox.
+func taskDialogFallback(config TaskDialogConfig) (int, error) {
+	var parts []string
+	for _, part := range []string{config.MainInstruction, config.Content, config.ExpandedInformation} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	if config.LearnMore {
+		parts = append(parts, "Learn more: "+WebView2Page)
+	}
+	flags := 0x00000001 | config.Icon.messageBoxFlags() // MB_OKCANCEL
+	result, err := MessageBoxEx(config.Owner, strings.Join(parts, "\n\n"), config.Title, flags)
+	if err != nil {
+		return -1, err
+	}
+	if result == DialogOK && len(config.Buttons) > 0 {
+		return config.Buttons[0].ID, nil
+	}
+	return result, nil
+}
+
+// packedStruct builds a byte packed C struct.
+type packedStruct []byte
+
+func (p *packedStruct) uint32(value uint32) {
+	var buffer [4]byte
+	binary.LittleEndian.PutUint32(buffer[:], value)
+	*p = append(*p, buffer[:]...)
+}
+
+func (p *packedStruct) pointer(value uintptr) {
+	if unsafe.Sizeof(value) == 4 {
+		p.uint32(uint32(value))
+		return
+	}
+	var buffer [8]byte
+	binary.LittleEndian.PutUint64(buffer[:], uint64(value))
+	*p = append(*p, buffer[:]...)
+}
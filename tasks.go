@@ -0,0 +1,44 @@
+package webview2runtime
+
+import (
+	"context"
+	"time"
+)
+
+// pendingTaskPollInterval is how often pending tasks are checked while waiting for them to finish.
+const pendingTaskPollInterval = time.Second
+
+// ScheduledTask is an EdgeUpdate scheduled task.
+type ScheduledTask struct {
+	Name string
+	// Status is the state of the task: Unknown, Disabled, Queued, Ready or Running.
+	Status string
+	// NextRunTime is when the task will next run, in local time as `2006-01-02 15:04:05`,
+	// or blank if it is not scheduled.
+	NextRunTime string
+}
+
+// Pending returns true if the task is currently running or queued to run.
+func (t ScheduledTask) Pending() bool {
+	return t.Status == "Running" || t.Status == "Queued"
+}
+
+// waitForPendingTasks waits until the source reports no pending tasks, the timeout expires or the
+// context is cancelled. Returns true if there are no pending tasks.
+func waitForPendingTasks(ctx context.Context, timeout time.Duration, source func() ([]ScheduledTask, error)) bool {
+	deadline := time.Now().Add(timeout)
+	for {
+		tasks, err := source()
+		if err != nil || len(tasks) == 0 {
+			return true
+		}
+		if time.Now().After(deadline) {
+			return false
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(pendingTaskPollInterval):
+		}
+	}
+}
@@ -0,0 +1,47 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// edgeUpdateTaskPrefix is the prefix of the scheduled tasks EdgeUpdate registers.
+const edgeUpdateTaskPrefix = "MicrosoftEdgeUpdateTask"
+
+// GetPendingUpdateTasks returns the EdgeUpdate scheduled tasks that are running or queued.
+// An install can hand off to these tasks to finish asynchronously, so the runtime may not be
+// detected until they complete. Returns nil if no EdgeUpdate tasks are pending.
+// The task state is read using the Task Scheduler API, so it works whatever the language of Windows.
+func GetPendingUpdateTasks() ([]ScheduledTask, error) {
+	tasks, err := queryScheduledTasks(edgeUpdateTaskPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query scheduled tasks: %w", err)
+	}
+	var result []ScheduledTask
+	for _, task := range tasks {
+		if task.Pending() {
+			result = append(result, task)
+		}
+	}
+	return result, nil
+}
+
+// waitForPendingUpdateTasks waits until there are no pending EdgeUpdate tasks, the timeout expires
+// or the context is cancelled. Returns true if there are no pending tasks.
+func waitForPendingUpdateTasks(ctx context.Context, timeout time.Duration) bool {
+	return waitForPendingTasks(ctx, timeout, GetPendingUpdateTasks)
+}
+
+// pendingTaskNames returns the names of the pending EdgeUpdate tasks, ignoring any error.
+func pendingTaskNames() []string {
+	tasks, _ := GetPendingUpdateTasks()
+	names := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		names = append(names, task.Name)
+	}
+	return names
+}
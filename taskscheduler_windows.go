@@ -0,0 +1,226 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"fmt"
+	"golang.org/x/sys/windows"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+var (
+	modole32             = syscall.NewLazyDLL("ole32.dll")
+	procCoCreateInstance = modole32.NewProc("CoCreateInstance")
+	modoleaut32          = syscall.NewLazyDLL("oleaut32.dll")
+	procSysAllocString   = modoleaut32.NewProc("SysAllocString")
+	procSysFreeString    = modoleaut32.NewProc("SysFreeString")
+)
+
+var (
+	clsidTaskScheduler = windows.GUID{Data1: 0x0f87369f, Data2: 0xa4e5, Data3: 0x4cfc, Data4: [8]byte{0xbd, 0x3e, 0x73, 0xe6, 0x15, 0x45, 0x72, 0xdd}}
+	iidITaskService    = windows.GUID{Data1: 0x2faba4c7, Data2: 0x4da9, Data3: 0x4013, Data4: [8]byte{0x96, 0x97, 0x20, 0xcc, 0x3f, 0xd4, 0x0f, 0x85}}
+)
+
+// The vtable indexes of the Task Scheduler methods used. Each interface derives from IDispatch,
+// whose seven methods come first.
+const (
+	methodRelease = 2
+
+	methodTaskServiceGetFolder = 7
+	methodTaskServiceConnect   = 10
+
+	methodTaskFolderGetTasks = 14
+
+	methodTaskCollectionCount = 7
+	methodTaskCollectionItem  = 8
+
+	methodTaskName        = 7
+	methodTaskState       = 9
+	methodTaskNextRunTime = 18
+)
+
+const (
+	_S_FALSE          = 1
+	_TASK_ENUM_HIDDEN = 1
+	_VT_I4            = 3
+)
+
+// taskStates are the names of the TASK_STATE values.
+var taskStates = map[int32]string{
+	0: "Unknown",
+	1: "Disabled",
+	2: "Queued",
+	3: "Ready",
+	4: "Running",
+}
+
+// variant is a VARIANT holding no value or a 32-bit integer.
+type variant struct {
+	vt       uint16
+	reserved [3]uint16
+	value    uintptr
+	extra    uintptr
+}
+
+// variantByReference is true where a VARIANT argument is passed as a pointer to a copy, as on
+// amd64 and arm64. On 32-bit Windows the VARIANT is pushed onto the stack as four words.
+const variantByReference = unsafe.Sizeof(uintptr(0)) == 8
+
+// comObject is a COM interface pointer.
+type comObject struct {
+	vtbl *[32]uintptr
+}
+
+// call calls the method at the given vtable index and returns its HRESULT as an error.
+//
+//go:uintptrescapes
+func (o *comObject) call(method int, args ...uintptr) error {
+	all := make([]uintptr, 18)
+	all[0] = uintptr(unsafe.Pointer(o))
+	copy(all[1:], args)
+	hr, _, _ := syscall.Syscall18(o.vtbl[method], uintptr(len(args)+1),
+		all[0], all[1], all[2], all[3], all[4], all[5], all[6], all[7], all[8],
+		all[9], all[10], all[11], all[12], all[13], all[14], all[15], all[16], all[17])
+	if int32(hr) < 0 {
+		return fmt.Errorf("HRESULT 0x%08x", uint32(hr))
+	}
+	return nil
+}
+
+func (o *comObject) release() {
+	if o != nil {
+		_ = o.call(methodRelease)
+	}
+}
+
+// queryScheduledTasks returns the scheduled tasks in the root folder of the Task Scheduler whose
+// names start with the given prefix, including hidden tasks. The state of each task is read from
+// the Task Scheduler API, so it does not depend on the language of Windows.
+func queryScheduledTasks(prefix string) ([]ScheduledTask, error) {
+	// COM is initialised for this thread only
+	runtime.LockOSThread()
+	defer runtime.UnlockOSThread()
+	// S_FALSE means COM was already initialised, which must still be balanced. A thread already in a
+	// single threaded apartment can use the task scheduler as it is.
+	err := windows.CoInitializeEx(0, windows.COINIT_MULTITHREADED)
+	switch err {
+	case nil, syscall.Errno(_S_FALSE):
+		defer windows.CoUninitialize()
+	case syscall.Errno(windows.RPC_E_CHANGED_MODE):
+	default:
+		return nil, fmt.Errorf("unable to initialise COM: %w", err)
+	}
+
+	var service *comObject
+	hr, _, _ := procCoCreateInstance.Call(
+		uintptr(unsafe.Pointer(&clsidTaskScheduler)),
+		0,
+		windows.CLSCTX_INPROC_SERVER,
+		uintptr(unsafe.Pointer(&iidITaskService)),
+		uintptr(unsafe.Pointer(&service)))
+	if int32(hr) < 0 {
+		return nil, fmt.Errorf("unable to create the task scheduler: HRESULT 0x%08x", uint32(hr))
+	}
+	defer service.release()
+
+	// Connect to the local machine as the current user
+	if variantByReference {
+		var empty [4]variant
+		err = service.call(methodTaskServiceConnect,
+			uintptr(unsafe.Pointer(&empty[0])), uintptr(unsafe.Pointer(&empty[1])),
+			uintptr(unsafe.Pointer(&empty[2])), uintptr(unsafe.Pointer(&empty[3])))
+	} else {
+		err = service.call(methodTaskServiceConnect, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to the task scheduler: %w", err)
+	}
+
+	root, err := syscall.UTF16PtrFromString(`\`)
+	if err != nil {
+		return nil, err
+	}
+	path, _, _ := procSysAllocString.Call(uintptr(unsafe.Pointer(root)))
+	if path == 0 {
+		return nil, fmt.Errorf("unable to allocate task folder path")
+	}
+	defer procSysFreeString.Call(path)
+	var folder *comObject
+	err = service.call(methodTaskServiceGetFolder, path, uintptr(unsafe.Pointer(&folder)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open the root task folder: %w", err)
+	}
+	defer folder.release()
+
+	var tasks *comObject
+	err = folder.call(methodTaskFolderGetTasks, _TASK_ENUM_HIDDEN, uintptr(unsafe.Pointer(&tasks)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list scheduled tasks: %w", err)
+	}
+	defer tasks.release()
+	var count int32
+	err = tasks.call(methodTaskCollectionCount, uintptr(unsafe.Pointer(&count)))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list scheduled tasks: %w", err)
+	}
+
+	var result []ScheduledTask
+	// The collection is indexed from 1
+	for i := int32(1); i <= count; i++ {
+		var task *comObject
+		if variantByReference {
+			index := variant{vt: _VT_I4, value: uintptr(i)}
+			err = tasks.call(methodTaskCollectionItem, uintptr(unsafe.Pointer(&index)), uintptr(unsafe.Pointer(&task)))
+		} else {
+			err = tasks.call(methodTaskCollectionItem, _VT_I4, 0, uintptr(i), 0, uintptr(unsafe.Pointer(&task)))
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read scheduled task %d: %w", i, err)
+		}
+		info, ok := readScheduledTask(task, prefix)
+		task.release()
+		if ok {
+			result = append(result, info)
+		}
+	}
+	return result, nil
+}
+
+// readScheduledTask reads the name, state and next run time of a registered task.
+// Returns false if the name of the task does not start with the prefix.
+func readScheduledTask(task *comObject, prefix string) (ScheduledTask, bool) {
+	var name *uint16
+	err := task.call(methodTaskName, uintptr(unsafe.Pointer(&name)))
+	if err != nil || name == nil {
+		return ScheduledTask{}, false
+	}
+	result := ScheduledTask{Name: windows.UTF16PtrToString(name)}
+	procSysFreeString.Call(uintptr(unsafe.Pointer(name)))
+	if !strings.HasPrefix(result.Name, prefix) {
+		return ScheduledTask{}, false
+	}
+
+	var state int32
+	result.Status = taskStates[0]
+	if task.call(methodTaskState, uintptr(unsafe.Pointer(&state))) == nil {
+		if status, ok := taskStates[state]; ok {
+			result.Status = status
+		}
+	}
+	var nextRun float64
+	if task.call(methodTaskNextRunTime, uintptr(unsafe.Pointer(&nextRun))) == nil && nextRun > 0 {
+		result.NextRunTime = oleDate(nextRun).Format("2006-01-02 15:04:05")
+	}
+	return result, true
+}
+
+// oleDate converts an OLE automation date, the days since midnight on 30 December 1899 in local time.
+func oleDate(days float64) time.Time {
+	epoch := time.Date(1899, 12, 30, 0, 0, 0, 0, time.Local)
+	return epoch.Add(time.Duration(days * float64(24*time.Hour)))
+}
@@ -0,0 +1,80 @@
+package webview2runtime
+
+import (
+	"sync"
+	"time"
+)
+
+// Telemetry receives the outcome of detections, downloads and installs, eg to forward them to a
+// metrics system so that fleet operators can track install success rates.
+// The methods are called synchronously, so should return quickly.
+type Telemetry interface {
+	// DetectionResult is called each time the installed runtime is detected for the caller.
+	DetectionResult(event DetectionTelemetry)
+	// Download is called after each attempt to download the bootstrapper from a URL.
+	Download(event DownloadTelemetry)
+	// InstallResult is called once an install has finished, whether or not it succeeded.
+	InstallResult(event InstallTelemetry)
+}
+
+// DetectionTelemetry describes a detection of the installed runtime.
+type DetectionTelemetry struct {
+	// Version is the installed version, or blank if the runtime is not installed.
+	Version  string
+	Duration time.Duration
+	Err      error
+}
+
+// DownloadTelemetry describes a download of the bootstrapper.
+type DownloadTelemetry struct {
+	URL string
+	// Bytes is the size of the downloaded file.
+	Bytes    int64
+	Duration time.Duration
+	Err      error
+}
+
+// InstallTelemetry describes a finished install.
+type InstallTelemetry struct {
+	Success  bool
+	ExitCode uint32
+	// Reason is the decoded meaning of ExitCode, or why the install failed without an exit code.
+	Reason   Reason
+	Scope    Scope
+	Duration time.Duration
+	// Err is the reason the install failed, if it did.
+	Err error
+}
+
+// NopTelemetry is a Telemetry that ignores every event. It is the default.
+type NopTelemetry struct{}
+
+// DetectionResult does nothing.
+func (NopTelemetry) DetectionResult(DetectionTelemetry) {}
+
+// Download does nothing.
+func (NopTelemetry) Download(DownloadTelemetry) {}
+
+// InstallResult does nothing.
+func (NopTelemetry) InstallResult(InstallTelemetry) {}
+
+var (
+	telemetryLock sync.Mutex
+	telemetry     Telemetry = NopTelemetry{}
+)
+
+// SetTelemetry sets the Telemetry this package reports to. Passing nil restores NopTelemetry.
+func SetTelemetry(t Telemetry) {
+	telemetryLock.Lock()
+	defer telemetryLock.Unlock()
+	if t == nil {
+		t = NopTelemetry{}
+	}
+	telemetry = t
+}
+
+func getTelemetry() Telemetry {
+	telemetryLock.Lock()
+	defer telemetryLock.Unlock()
+	return telemetry
+}
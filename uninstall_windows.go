@@ -0,0 +1,111 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+	"fmt"
+	"golang.org/x/sys/windows"
+	"time"
+)
+
+// Exit codes returned by the runtime's setup.exe when uninstalling.
+const (
+	uninstallExitCodeSuccess = 19 // UNINSTALL_SUCCESSFUL
+)
+
+var uninstallExitCodeMessages = map[uint32]string{
+	14: "Administrator rights are required to uninstall",
+	15: "The runtime is not installed",
+	16: "The runtime is in use. Close all applications using it and try again",
+	20: "The uninstall failed",
+	21: "The uninstall was cancelled",
+}
+
+// uninstallTimeout is how long to wait for the registration to be removed after uninstalling.
+const uninstallTimeout = 30 * time.Second
+
+// Uninstall runs the SilentUninstall command recorded for the installation and waits for it to finish.
+// Per-machine installations are uninstalled with elevation if needed. Once the command has exited,
+// the registration of the installation is checked to have been removed. Waits for any install to finish first.
+// Returns an error wrapping ErrInstallerExit if the uninstall command fails.
+func (i *Info) Uninstall(ctx context.Context) error {
+	if i.SilentUninstall == "" {
+		return fmt.Errorf("no uninstall command is recorded for webview2 runtime %s", i.Version)
+	}
+	args, err := windows.DecomposeCommandLine(i.SilentUninstall)
+	if err != nil || len(args) == 0 {
+		return fmt.Errorf("invalid uninstall command: %s", i.SilentUninstall)
+	}
+
+	if processes, err := i.Processes(); err == nil && len(processes) > 0 {
+		logEvent("runtime in use", "version", i.Version, "processes", len(processes))
+	}
+
+	options := &InstallOptions{HideWindow: true}
+	if i.Scope == ScopeUser {
+		// Elevating could run the command as a different user
+		options.Elevation = ElevationNever
+	}
+	release, _, err := acquireInstallLock(ctx, options)
+	if err != nil {
+		return err
+	}
+	defer release()
+	exitCode, err := launch(ctx, args[0], args[1:], options)
+	if err != nil {
+		return err
+	}
+	if exitCode != exitCodeSuccess && exitCode != uninstallExitCodeSuccess {
+		message, ok := uninstallExitCodeMessages[exitCode]
+		if !ok {
+			message = ExitCodeMessage(int(exitCode))
+		}
+		return fmt.Errorf("%w: uninstaller exited with code %d: %s", ErrInstallerExit, exitCode, message)
+	}
+	return i.waitForUnregistered(ctx)
+}
+
+// UninstallWhenIdle is the same as Uninstall but first handles any apps using the runtime according
+// to the given options. See WaitForIdle.
+func (i *Info) UninstallWhenIdle(ctx context.Context, options InUseOptions) error {
+	err := i.WaitForIdle(ctx, options)
+	if err != nil {
+		return err
+	}
+	return i.Uninstall(ctx)
+}
+
+// waitForUnregistered waits for the registration of the installation to be removed from the registry.
+func (i *Info) waitForUnregistered(ctx context.Context) error {
+	deadline := time.Now().Add(uninstallTimeout)
+	for {
+		registered, err := i.isRegistered()
+		if err != nil || !registered {
+			return err
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("webview2 runtime %s is still registered after uninstalling", i.Version)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(processPollInterval):
+		}
+	}
+}
+
+// isRegistered returns true if the registry still has a registration for this installation.
+func (i *Info) isRegistered() (bool, error) {
+	registrations, err := defaultDetector.Registrations()
+	if err != nil {
+		return false, err
+	}
+	for _, registration := range registrations {
+		if registration.Info.Scope == i.Scope && registration.Info.Version == i.Version {
+			return true, nil
+		}
+	}
+	return false, nil
+}
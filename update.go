@@ -0,0 +1,12 @@
+package webview2runtime
+
+// UpdateInfo compares the installed runtime with the latest evergreen release.
+type UpdateInfo struct {
+	// InstalledVersion is the installed version, or blank if the runtime is not installed.
+	InstalledVersion string
+	// LatestVersion is the latest version available from Microsoft.
+	LatestVersion string
+	// UpdateAvailable is true if the installed version is older than the latest version,
+	// or the runtime is not installed.
+	UpdateAvailable bool
+}
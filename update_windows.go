@@ -0,0 +1,57 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+	"errors"
+)
+
+// CheckForUpdate compares the installed runtime with the latest evergreen release available from Microsoft.
+// Nothing is downloaded or installed.
+// Returns an error if the latest version could not be queried.
+func CheckForUpdate(ctx context.Context) (*UpdateInfo, error) {
+	latest, err := QueryLatestVersion(ctx, ChannelStable)
+	if err != nil {
+		return nil, err
+	}
+	info := &UpdateInfo{
+		InstalledVersion: GetInstalledVersion(),
+		LatestVersion:    latest,
+	}
+	info.UpdateAvailable = info.InstalledVersion == "" || CompareVersions(info.InstalledVersion, latest) < 0
+	return info, nil
+}
+
+// Upgrade brings the runtime up to date with the latest evergreen release by asking EdgeUpdate to update it,
+// or by running the bootstrapper if EdgeUpdate is not installed. Useful where automatic updates are broken,
+// eg on kiosk machines.
+// If the runtime is already up to date, nothing is run and the result is successful with ReasonAlreadyInstalled.
+// Unless ExpectedVersion is set, the install is verified against the latest version, allowing newer versions.
+// The options may be nil. The result is never nil.
+func Upgrade(ctx context.Context, options *InstallOptions) (*InstallResult, error) {
+	update, err := CheckForUpdate(ctx)
+	if err != nil {
+		return &InstallResult{Error: err}, err
+	}
+	if !update.UpdateAvailable {
+		logEvent("runtime is up to date", "version", update.InstalledVersion)
+		return &InstallResult{Success: true, Reason: ReasonAlreadyInstalled}, nil
+	}
+	logEvent("upgrading runtime", "from", update.InstalledVersion, "to", update.LatestVersion)
+
+	var upgradeOptions InstallOptions
+	if options != nil {
+		upgradeOptions = *options
+	}
+	if upgradeOptions.ExpectedVersion == "" {
+		upgradeOptions.ExpectedVersion = update.LatestVersion
+		upgradeOptions.AllowNewerVersion = true
+	}
+	result, err := UpdateUsingEdgeUpdate(ctx, &upgradeOptions)
+	if errors.Is(err, ErrEdgeUpdateNotFound) {
+		return InstallUsingBootstrapperWithResult(ctx, &upgradeOptions)
+	}
+	return result, err
+}
@@ -0,0 +1,128 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"fmt"
+	"golang.org/x/sys/windows"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// userDataCaches are the cache folders in a user data folder, relative to the profile folder.
+// They are recreated by the runtime as needed, so removing them never loses user data.
+var userDataCaches = []string{
+	"Cache",
+	"Code Cache",
+	"GPUCache",
+	filepath.Join("Service Worker", "CacheStorage"),
+	filepath.Join("Service Worker", "ScriptCache"),
+}
+
+// userDataSharedCaches are the cache folders shared by all profiles, relative to the EBWebView folder.
+var userDataSharedCaches = []string{
+	"ShaderCache",
+	"GrShaderCache",
+}
+
+// RecommendedUserDataFolder returns the recommended user data folder for the given app:
+// `%LOCALAPPDATA%\<appName>\WebView2`. The default, a folder next to the executable, is not
+// writable when the app is installed under Program Files.
+func RecommendedUserDataFolder(appName string) (string, error) {
+	if appName == "" || appName == "." || appName == ".." || strings.ContainsAny(appName, `\/:*?"<>|`) {
+		return "", fmt.Errorf("invalid app name: %q", appName)
+	}
+	localAppData := os.Getenv("LOCALAPPDATA")
+	if localAppData == "" {
+		return "", fmt.Errorf("LOCALAPPDATA is not set")
+	}
+	return filepath.Join(localAppData, appName, "WebView2"), nil
+}
+
+// CreateUserDataFolder creates the user data folder, if needed, and restricts it to the current user,
+// SYSTEM and Administrators so other users cannot read the cookies and credentials stored in it.
+func CreateUserDataFolder(path string) error {
+	err := os.MkdirAll(path, 0700)
+	if err != nil {
+		return fmt.Errorf("unable to create user data folder: %w", err)
+	}
+	user, err := windows.GetCurrentProcessToken().GetTokenUser()
+	if err != nil {
+		return fmt.Errorf("unable to get the current user: %w", err)
+	}
+	sddl := fmt.Sprintf("D:P(A;OICI;FA;;;%s)(A;OICI;FA;;;SY)(A;OICI;FA;;;BA)", user.User.Sid.String())
+	descriptor, err := windows.SecurityDescriptorFromString(sddl)
+	if err != nil {
+		return err
+	}
+	dacl, _, err := descriptor.DACL()
+	if err != nil {
+		return err
+	}
+	err = windows.SetNamedSecurityInfo(path, windows.SE_FILE_OBJECT,
+		windows.DACL_SECURITY_INFORMATION|windows.PROTECTED_DACL_SECURITY_INFORMATION, nil, nil, dacl, nil)
+	if err != nil {
+		return fmt.Errorf("unable to set the permissions of the user data folder: %w", err)
+	}
+	return nil
+}
+
+// UserDataFolderSize returns the total size in bytes of the files in the user data folder.
+func UserDataFolderSize(path string) (int64, error) {
+	var size int64
+	err := filepath.Walk(path, func(_ string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size, err
+}
+
+// ClearUserDataFolderCache removes the caches from the user data folder, for every profile, leaving
+// cookies, local storage and other user data in place. Apps using the folder must be closed first.
+// Returns an error if a cache could not be removed, eg because the folder is in use.
+func ClearUserDataFolderCache(path string) error {
+	browserFolder := filepath.Join(path, "EBWebView")
+	var folders []string
+	for _, cache := range userDataSharedCaches {
+		folders = append(folders, filepath.Join(browserFolder, cache))
+	}
+	entries, err := os.ReadDir(browserFolder)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() || !exists(filepath.Join(browserFolder, entry.Name(), "Preferences")) {
+			continue
+		}
+		for _, cache := range userDataCaches {
+			folders = append(folders, filepath.Join(browserFolder, entry.Name(), cache))
+		}
+	}
+
+	var firstErr error
+	for _, folder := range folders {
+		err := os.RemoveAll(folder)
+		if err != nil {
+			logEvent("unable to clear cache", "path", folder, "error", err)
+			if firstErr == nil {
+				if isInUse(err) {
+					err = fmt.Errorf("the user data folder is in use: %w", err)
+				}
+				firstErr = err
+			}
+			continue
+		}
+		logEvent("cleared cache", "path", folder)
+	}
+	return firstErr
+}
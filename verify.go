@@ -0,0 +1,47 @@
+package webview2runtime
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// The delays between detections made by VerifyInstalled. The delay doubles after each attempt.
+const (
+	verifyInitialDelay = 250 * time.Millisecond
+	verifyMaximumDelay = 5 * time.Second
+)
+
+// defaultVerifyTimeout is how long an install waits for the runtime to be detected once the installer has exited.
+const defaultVerifyTimeout = 30 * time.Second
+
+// waitUntilInstalled is the same as VerifyInstalled but uses this Detector and waits for the given channel.
+func (d *Detector) waitUntilInstalled(ctx context.Context, channel Channel, minVersion string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	delay := verifyInitialDelay
+	for {
+		info, err := d.channelInstallation(channel)
+		if err == nil && info != nil && (minVersion == "" || CompareVersions(info.Version, minVersion) >= 0) {
+			logEvent("runtime verified", "version", info.Version)
+			return nil
+		}
+		if time.Now().After(deadline) {
+			switch {
+			case err != nil:
+				return fmt.Errorf("%w: %s", ErrNotInstalled, err)
+			case info != nil:
+				return fmt.Errorf("%w: found version %s but %s or newer is required", ErrNotInstalled, info.Version, minVersion)
+			}
+			return fmt.Errorf("%w: not detected within %s of installing", ErrNotInstalled, timeout)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+		delay *= 2
+		if delay > verifyMaximumDelay {
+			delay = verifyMaximumDelay
+		}
+	}
+}
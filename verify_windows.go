@@ -0,0 +1,19 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+	"time"
+)
+
+// VerifyInstalled waits for a runtime at least as new as minVersion to be detected, using both
+// WebView2Loader.dll and the registry. The bootstrapper can exit before EdgeUpdate has finished
+// registering the runtime, so detection is retried with an increasing delay until the runtime
+// appears or the timeout expires. If minVersion is blank, any version is accepted.
+// Returns nil once the runtime is detected, otherwise an error wrapping ErrNotInstalled, or the
+// error of the context if it is done first.
+func VerifyInstalled(ctx context.Context, minVersion string, timeout time.Duration) error {
+	return defaultDetector.waitUntilInstalled(ctx, ChannelStable, minVersion, timeout)
+}
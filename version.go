@@ -0,0 +1,82 @@
+package webview2runtime
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// CompareVersions compares two webview2 runtime versions, eg `91.0.864.59`, without needing WebView2Loader.dll.
+// Any channel suffix, eg `92.0.902.0 dev`, is ignored. Missing or invalid components are treated as 0.
+// Returns -1, 0 or 1 if a is older than, the same as or newer than b.
+func CompareVersions(a string, b string) int {
+	aParts := lenientParseVersion(a)
+	bParts := lenientParseVersion(b)
+	result, _ := compareParts(aParts, bParts)
+	return result
+}
+
+// lenientParseVersion parses a version, treating invalid components as 0.
+func lenientParseVersion(version string) []uint64 {
+	parts := strings.Split(stripChannel(version), ".")
+	result := make([]uint64, len(parts))
+	for i, part := range parts {
+		result[i], _ = strconv.ParseUint(part, 10, 32)
+	}
+	return result
+}
+
+// stripChannel removes the channel suffix the loader adds to preview channel versions, eg `92.0.902.0 dev`.
+func stripChannel(version string) string {
+	return strings.TrimSpace(strings.SplitN(strings.TrimSpace(version), " ", 2)[0])
+}
+
+// compareVersions compares two dotted numeric versions, eg `1.3.147.37`.
+// Missing components are treated as 0.
+// Returns -1, 0 or 1 if a is older than, the same as or newer than b.
+// Returns an error if either version is invalid.
+func compareVersions(a string, b string) (int, error) {
+	aParts, err := parseVersion(a)
+	if err != nil {
+		return 0, err
+	}
+	bParts, err := parseVersion(b)
+	if err != nil {
+		return 0, err
+	}
+	return compareParts(aParts, bParts)
+}
+
+func compareParts(aParts []uint64, bParts []uint64) (int, error) {
+	for len(aParts) < len(bParts) {
+		aParts = append(aParts, 0)
+	}
+	for len(bParts) < len(aParts) {
+		bParts = append(bParts, 0)
+	}
+	for i := range aParts {
+		switch {
+		case aParts[i] < bParts[i]:
+			return -1, nil
+		case aParts[i] > bParts[i]:
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+func parseVersion(version string) ([]uint64, error) {
+	if version == "" {
+		return nil, fmt.Errorf("invalid version: blank")
+	}
+	parts := strings.Split(version, ".")
+	result := make([]uint64, len(parts))
+	for i, part := range parts {
+		number, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version: %s", version)
+		}
+		result[i] = number
+	}
+	return result, nil
+}
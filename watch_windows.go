@@ -0,0 +1,175 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"context"
+	"fmt"
+	"golang.org/x/sys/windows"
+	"golang.org/x/sys/windows/registry"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// watchSettleDelay is how long to wait after a registry change before detecting the runtime,
+// so that EdgeUpdate has finished writing all the values of an install or update.
+const watchSettleDelay = time.Second
+
+// watchPollInterval is how often the watch checks whether its context is done.
+const watchPollInterval = 500 * time.Millisecond
+
+// WatchInstallation watches the EdgeUpdate client keys for changes and sends the installed runtime
+// each time it is installed, updated or removed. Long-lived apps can use it to react when EdgeUpdate
+// upgrades the runtime underneath them, eg by prompting for a restart. A removal is sent as an Info
+// with a blank Version. The installation at the time of the call is not sent.
+// Each change also invalidates the detection cache, see SetDetectionCacheTTL.
+// The channel is closed once the context is done.
+// Returns an error if the installation could not be detected or the registry could not be watched.
+func WatchInstallation(ctx context.Context) (<-chan Info, error) {
+	current, err := defaultDetector.Detect()
+	if err != nil {
+		return nil, err
+	}
+	event, err := windows.CreateEvent(nil, 0, 0, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(chan Info)
+	started := make(chan error, 1)
+	go func() {
+		// Registry notifications are tied to the thread that requested them
+		runtime.LockOSThread()
+		defer runtime.UnlockOSThread()
+		defer windows.CloseHandle(event)
+		keys, err := openWatchKeys(event)
+		started <- err
+		if err != nil {
+			return
+		}
+		defer close(changes)
+		defer func() {
+			closeKeys(keys)
+		}()
+
+		last := infoOrEmpty(current)
+		for {
+			status, err := windows.WaitForSingleObject(event, uint32(watchPollInterval/time.Millisecond))
+			if ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				logEvent("unable to watch registry", "error", err)
+				return
+			}
+			if status == uint32(windows.WAIT_TIMEOUT) {
+				continue
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(watchSettleDelay):
+			}
+			InvalidateDetectionCache()
+			// The keys are opened again as keys that did not exist before may have been created
+			closeKeys(keys)
+			keys, err = openWatchKeys(event)
+			if err != nil {
+				logEvent("unable to watch registry", "error", err)
+				return
+			}
+			info, err := defaultDetector.Detect()
+			if err != nil {
+				logEvent("unable to detect runtime", "error", err)
+				continue
+			}
+			next := infoOrEmpty(info)
+			if next.Version == last.Version && next.Scope == last.Scope && next.Location == last.Location {
+				continue
+			}
+			logEvent("runtime changed", "from", last.Version, "to", next.Version)
+			last = next
+			select {
+			case changes <- next:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	err = <-started
+	if err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// openWatchKeys opens the runtime client keys, or their nearest existing parent, and requests
+// that the event is signalled when anything beneath them changes.
+func openWatchKeys(event windows.Handle) ([]registry.Key, error) {
+	var keys []registry.Key
+	opened := map[string]bool{}
+	for _, path := range runtimeKeys(nativeArchitecture()) {
+		key, name, err := openNearestKey(path)
+		if err != nil {
+			closeKeys(keys)
+			return nil, err
+		}
+		if opened[name] {
+			closeKeys([]registry.Key{key})
+			continue
+		}
+		opened[name] = true
+		keys = append(keys, key)
+		err = windows.RegNotifyChangeKeyValue(windows.Handle(key), true, windows.REG_NOTIFY_CHANGE_NAME|windows.REG_NOTIFY_CHANGE_LAST_SET, event, true)
+		if err != nil {
+			closeKeys(keys)
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// openNearestKey opens the given key for notifications, or its nearest parent that exists.
+// Returns the key that was opened along with its path.
+func openNearestKey(path string) (registry.Key, string, error) {
+	parts := strings.SplitN(path, `\`, 2)
+	root, ok := registryRoots[parts[0]]
+	if !ok || len(parts) != 2 {
+		return 0, "", fmt.Errorf("unsupported registry key: %s", path)
+	}
+	subkey := parts[1]
+	for {
+		key, err := registry.OpenKey(root, subkey, registry.NOTIFY|registry.WOW64_64KEY)
+		if err == nil {
+			return key, parts[0] + `\` + subkey, nil
+		}
+		if err != registry.ErrNotExist {
+			return 0, "", err
+		}
+		index := strings.LastIndex(subkey, `\`)
+		if index < 0 {
+			return root, parts[0], nil
+		}
+		subkey = subkey[:index]
+	}
+}
+
+// closeKeys closes the given keys, leaving the predefined root keys open.
+func closeKeys(keys []registry.Key) {
+	for _, key := range keys {
+		if key != registry.LOCAL_MACHINE && key != registry.CURRENT_USER {
+			key.Close()
+		}
+	}
+}
+
+// infoOrEmpty returns the installation, or an empty Info if the runtime is not installed.
+func infoOrEmpty(info *Info) Info {
+	if info == nil {
+		return Info{}
+	}
+	return *info
+}
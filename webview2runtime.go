@@ -1,195 +1,33 @@
-//go:build windows
-// +build windows
-
 package webview2runtime
 
 import (
-	_ "embed"
-	"fmt"
-	"golang.org/x/sys/windows"
-	"io"
-	"net/http"
-	"os"
-	"os/exec"
-	"path/filepath"
-	"syscall"
-	"unsafe"
+	"time"
 )
 
-//go:embed MicrosoftEdgeWebview2Setup.exe
-var setupexe []byte
-
 // Info contains all the information about an installation of the webview2 runtime.
 type Info struct {
 	Location        string
 	Name            string
 	Version         string
 	SilentUninstall string
-}
-
-// IsOlderThan returns true if the installed version is older than the given required version.
-// Returns error if something goes wrong.
-func (i *Info) IsOlderThan(requiredVersion string) (bool, error) {
-	var mod = syscall.NewLazyDLL("WebView2Loader.dll")
-	var CompareBrowserVersions = mod.NewProc("CompareBrowserVersions")
-	v1, err := syscall.UTF16PtrFromString(i.Version)
-	if err != nil {
-		return false, err
-	}
-	v2, err := syscall.UTF16PtrFromString(requiredVersion)
-	if err != nil {
-		return false, err
-	}
-	var result int = 9
-	_, _, err = CompareBrowserVersions.Call(uintptr(unsafe.Pointer(v1)), uintptr(unsafe.Pointer(v2)), uintptr(unsafe.Pointer(&result)))
-	if result < -1 || result > 1 {
-		return false, err
-	}
-	return result == -1, nil
-}
-
-// GetInstalledVersion returns the installed version of the webview2 runtime.
-// If there is no version installed, a blank string is returned.
-func GetInstalledVersion() string {
-	var mod = syscall.NewLazyDLL("WebView2Loader")
-	var GetAvailableCoreWebView2BrowserVersionString = mod.NewProc("GetAvailableCoreWebView2BrowserVersionString")
-
-	err := mod.Load()
-	if err == nil {
-		err = GetAvailableCoreWebView2BrowserVersionString.Find()
-	}
-	if err != nil {
-		return ""
-	}
-
-	var result *uint16
-	res, _, _ := GetAvailableCoreWebView2BrowserVersionString.Call(
-		uintptr(unsafe.Pointer(nil)),
-		uintptr(unsafe.Pointer(&result)),
-	)
-	if res != 0 {
-		return ""
-	}
-	version := windows.UTF16PtrToString(result)
-	windows.CoTaskMemFree(unsafe.Pointer(result))
-	return version
-}
-func downloadBootstrapper() (string, error) {
-	bootstrapperURL := `https://go.microsoft.com/fwlink/p/?LinkId=2124703`
-	installer := filepath.Join(os.TempDir(), `MicrosoftEdgeWebview2Setup.exe`)
-
-	// Download installer
-	out, err := os.Create(installer)
-	defer out.Close()
-	if err != nil {
-		return "", err
-	}
-	resp, err := http.Get(bootstrapperURL)
-	defer resp.Body.Close()
-	if err != nil {
-		err = out.Close()
-		return "", err
-	}
-	_, err = io.Copy(out, resp.Body)
-	if err != nil {
-		return "", err
-	}
-
-	return installer, nil
-}
-
-// InstallUsingEmbeddedBootstrapper will download the bootstrapper from Microsoft and run it to install
-// the latest version of the runtime.
-// Returns true if the installer ran successfully.
-// Returns an error if something goes wrong
-func InstallUsingEmbeddedBootstrapper() (bool, error) {
-
-	installer := filepath.Join(os.TempDir(), `MicrosoftEdgeWebview2Setup.exe`)
-	err := os.WriteFile(installer, setupexe, 0755)
-	if err != nil {
-		return false, err
-	}
-	result, err := runInstaller(installer)
-	if err != nil {
-		return false, err
-	}
-
-	return result, os.Remove(installer)
-
-}
-
-// InstallUsingBootstrapper will extract the embedded bootstrapper from Microsoft and run it to install
-// the latest version of the runtime.
-// Returns true if the installer ran successfully.
-// Returns an error if something goes wrong
-func InstallUsingBootstrapper() (bool, error) {
-
-	installer, err := downloadBootstrapper()
-	if err != nil {
-		return false, err
-	}
-
-	result, err := runInstaller(installer)
-	if err != nil {
-		return false, err
-	}
-
-	return result, os.Remove(installer)
-
-}
-
-func runInstaller(installer string) (bool, error) {
-	err := ShellExecuteAndWait(0, "runas", installer, "", os.Getenv("TMP"), syscall.SW_NORMAL)
-	if err != nil {
-		fmt.Println(err)
-		return false, err
-	}
-	return true, nil
-}
-
-// Confirm will prompt the user with a message and OK / CANCEL buttons.
-// Returns true if OK is selected by the user.
-// Returns an error if something went wrong.
-func Confirm(caption string, title string) (bool, error) {
-	var flags uint = 0x00000001 // MB_OKCANCEL
-	result, err := MessageBox(caption, title, flags)
-	if err != nil {
-		return false, err
-	}
-	return result == 1, nil
-}
-
-// Error will an error message to the user.
-// Returns an error if something went wrong.
-func Error(caption string, title string) error {
-	var flags uint = 0x00000010 // MB_ICONERROR
-	_, err := MessageBox(caption, title, flags)
-	return err
-}
-
-// MessageBox prompts the user with the given caption and title.
-// Flags may be provided to customise the dialog.
-// Returns an error if something went wrong.
-func MessageBox(caption string, title string, flags uint) (int, error) {
-	captionUTF16, err := syscall.UTF16PtrFromString(caption)
-	if err != nil {
-		return -1, err
-	}
-	titleUTF16, err := syscall.UTF16PtrFromString(title)
-	if err != nil {
-		return -1, err
-	}
-	ret, _, _ := syscall.NewLazyDLL("user32.dll").NewProc("MessageBoxW").Call(
-		uintptr(0),
-		uintptr(unsafe.Pointer(captionUTF16)),
-		uintptr(unsafe.Pointer(titleUTF16)),
-		uintptr(flags))
-
-	return int(ret), nil
-}
-
-// OpenInstallerDownloadWebpage will open the browser on the WebView2 download page
-func OpenInstallerDownloadWebpage() error {
-	cmd := exec.Command("rundll32", "url.dll,FileProtocolHandler", "https://developer.microsoft.com/en-us/microsoft-edge/webview2/")
-	return cmd.Run()
+	// Scope is where the runtime is installed: per-machine or per-user.
+	Scope Scope
+	// DetectionMethod is the mechanism that found this installation.
+	DetectionMethod DetectionMethod
+	// Channel is the release channel of the installation. Preview channels of Edge can be used
+	// by WebView2 when the runtime is not installed.
+	Channel Channel
+	// RegistryVersion is the version registered in the registry when it differs from the
+	// version reported by WebView2Loader.dll, eg after a partial uninstall. Otherwise blank.
+	RegistryVersion string
+	// InstallDate is when the runtime was installed, if recorded in the registry. Otherwise the zero time.
+	InstallDate time.Time
+	// RegisteredArchitecture is the architecture named in the EdgeUpdate `ap` value, if any.
+	// The Architecture method reads the architecture from the runtime binary instead.
+	RegisteredArchitecture Arch
+	// UpdatePolicy is the effective group policy for updating the installation.
+	UpdatePolicy UpdatePolicy
+	// Values are the raw values of the EdgeUpdate client and client state keys the installation
+	// is registered under. Nil if it was not found in the registry.
+	Values map[string]string
 }
@@ -0,0 +1,564 @@
+//go:build !windows
+// +build !windows
+
+package webview2runtime
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// This file lets code that supports several platforms call the API unconditionally. The runtime only
+// exists on Windows, so on other platforms nothing is ever installed, detection finds nothing,
+// Status returns StatusNotApplicable and anything that would install or prompt returns ErrUnsupportedPlatform.
+// The types, and a Detector given its own RegistryReader and Loader, work the same as on Windows.
+
+// systemRegistry finds no registry keys on this platform.
+type systemRegistry struct{}
+
+func (systemRegistry) ReadValues(key string) (map[string]string, error) {
+	return nil, nil
+}
+
+// systemLoader finds no runtime on this platform.
+type systemLoader struct{}
+
+func (systemLoader) AvailableBrowserVersion() (string, error) {
+	return "", ErrUnsupportedPlatform
+}
+
+func (systemLoader) CompareBrowserVersions(v1 string, v2 string) (int, error) {
+	return compareVersions(stripChannel(v1), stripChannel(v2))
+}
+
+// diagnoseSystem reports that the runtime does not apply on this platform.
+func diagnoseSystem(minVersion string) (DiagnosticsResult, int) {
+	return DiagnosticsResult{Status: "not-applicable", MinVersion: minVersion}, DiagnosticsNotApplicable
+}
+
+// nativeArchitecture returns the architecture of the current process.
+func nativeArchitecture() Arch {
+	return processArchitecture()
+}
+
+func getFileVersion(path string) (string, error) {
+	return "", ErrUnsupportedPlatform
+}
+
+// IsOlderThan returns ErrUnsupportedPlatform.
+func (i *Info) IsOlderThan(requiredVersion string) (bool, error) {
+	return false, ErrUnsupportedPlatform
+}
+
+// GetInstalledVersion returns a blank string as the runtime is never installed on this platform.
+func GetInstalledVersion() string {
+	return ""
+}
+
+// GetInstalledVersionTimed is the same as GetInstalledVersion but also returns how long detection took.
+func GetInstalledVersionTimed() (string, time.Duration) {
+	return "", 0
+}
+
+// Detect returns nil as the runtime is never installed on this platform.
+func Detect() (*Info, error) {
+	return nil, nil
+}
+
+// DetectJSON returns `null` as the runtime is never installed on this platform.
+func DetectJSON() ([]byte, error) {
+	return []byte("null"), nil
+}
+
+// DetectEffective returns nil as the runtime is never installed on this platform.
+func DetectEffective() (*Info, error) {
+	return nil, nil
+}
+
+// EffectiveVersion returns a blank version and ScopeNone as the runtime is never installed on this platform.
+func EffectiveVersion() (string, Scope, error) {
+	return "", ScopeNone, nil
+}
+
+// SetDetectionCacheTTL does nothing as detection always finds nothing on this platform.
+func SetDetectionCacheTTL(ttl time.Duration) {}
+
+// InvalidateDetectionCache does nothing as nothing is cached on this platform.
+func InvalidateDetectionCache() {}
+
+// GetInstallation returns nil as the runtime is never installed on this platform.
+func GetInstallation() (*Info, error) {
+	return nil, nil
+}
+
+// DetectChannels returns nil as no channel of Edge is installed on this platform.
+func DetectChannels() ([]Info, error) {
+	return nil, nil
+}
+
+// ListInstallations returns nil as the runtime is never installed on this platform.
+func ListInstallations() ([]Info, error) {
+	return nil, nil
+}
+
+// DetectConflicts returns nil as the runtime is never registered on this platform.
+func DetectConflicts() ([]Conflict, error) {
+	return nil, nil
+}
+
+// DetectOrphanedRuntime returns nil as the runtime is never registered on this platform.
+func DetectOrphanedRuntime() ([]OrphanedRuntime, error) {
+	return nil, nil
+}
+
+// CheckRuntimeHealth returns an empty RuntimeHealth as the runtime is never installed on this platform.
+func CheckRuntimeHealth() (*RuntimeHealth, error) {
+	return &RuntimeHealth{}, nil
+}
+
+// ValidateInstallation returns ErrUnsupportedPlatform.
+func ValidateInstallation(info *Info) (*HealthReport, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// Repair returns ErrUnsupportedPlatform. The result is never nil.
+func (r *HealthReport) Repair(ctx context.Context, options *RepairOptions) (*InstallResult, error) {
+	return unsupportedResult()
+}
+
+// CheckPolicies returns nil as there are no group policies on this platform.
+func CheckPolicies() ([]PolicyFinding, error) {
+	return nil, nil
+}
+
+// CanInstallPerUser returns ErrUnsupportedPlatform.
+func CanInstallPerUser() (bool, error) {
+	return false, ErrUnsupportedPlatform
+}
+
+// RecommendDistribution returns ErrUnsupportedPlatform.
+func RecommendDistribution() (*Recommendation, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// RecommendDistribution returns ErrUnsupportedPlatform.
+func (d *Detector) RecommendDistribution() (*Recommendation, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// Status returns StatusNotApplicable.
+func Status(minVersion string) (RuntimeStatus, error) {
+	return StatusNotApplicable, nil
+}
+
+// VerifyInstalled returns ErrUnsupportedPlatform.
+func VerifyInstalled(ctx context.Context, minVersion string, timeout time.Duration) error {
+	return ErrUnsupportedPlatform
+}
+
+// MustBeInstalled returns ErrUnsupportedPlatform.
+func MustBeInstalled(minVersion string) error {
+	return ErrUnsupportedPlatform
+}
+
+// WatchInstallation returns ErrUnsupportedPlatform.
+func WatchInstallation(ctx context.Context) (<-chan Info, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// GetProcessRuntime returns ErrUnsupportedPlatform.
+func GetProcessRuntime(pid uint32) (*Info, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// ListRuntimeProcesses returns nil as the runtime never runs on this platform.
+func ListRuntimeProcesses() ([]ProcessInfo, error) {
+	return nil, nil
+}
+
+// Processes returns nil as the runtime never runs on this platform.
+func (i *Info) Processes() ([]ProcessInfo, error) {
+	return nil, nil
+}
+
+// WaitForIdle returns ErrUnsupportedPlatform.
+func (i *Info) WaitForIdle(ctx context.Context, options InUseOptions) error {
+	return ErrUnsupportedPlatform
+}
+
+// Uninstall returns ErrUnsupportedPlatform.
+func (i *Info) Uninstall(ctx context.Context) error {
+	return ErrUnsupportedPlatform
+}
+
+// UninstallWhenIdle returns ErrUnsupportedPlatform.
+func (i *Info) UninstallWhenIdle(ctx context.Context, options InUseOptions) error {
+	return ErrUnsupportedPlatform
+}
+
+// UninstallPlan returns ErrUnsupportedPlatform.
+func (i *Info) UninstallPlan() (*Plan, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// GetPendingUpdateTasks returns nil as EdgeUpdate never runs on this platform.
+func GetPendingUpdateTasks() ([]ScheduledTask, error) {
+	return nil, nil
+}
+
+// EdgeUpdatePath returns ErrEdgeUpdateNotFound.
+func EdgeUpdatePath(scope Scope) (string, error) {
+	return "", ErrEdgeUpdateNotFound
+}
+
+// IsOSSupported returns false.
+func IsOSSupported() (bool, OSSupport) {
+	return false, OSSupport{Message: "The WebView2 runtime is only available on Windows"}
+}
+
+// IsElevated returns false.
+func IsElevated() bool {
+	return false
+}
+
+// SetLoaderPath does nothing as WebView2Loader.dll cannot be loaded on this platform.
+func SetLoaderPath(path string) {}
+
+// SetLoaderBytes does nothing as WebView2Loader.dll cannot be loaded on this platform.
+func SetLoaderBytes(dll []byte) {}
+
+// VerifyLoaderBitness returns ErrUnsupportedPlatform.
+func VerifyLoaderBitness() (bool, error) {
+	return false, ErrUnsupportedPlatform
+}
+
+// VerifySignature returns ErrUnsupportedPlatform.
+func VerifySignature(path string) (SignatureInfo, error) {
+	return SignatureInfo{}, ErrUnsupportedPlatform
+}
+
+// UserLanguage returns a blank string, so DefaultMessages returns the English messages.
+func UserLanguage() string {
+	return ""
+}
+
+// GenerateDiagnostics returns ErrUnsupportedPlatform. The report is always returned.
+func GenerateDiagnostics() (*DiagnosticsReport, error) {
+	return &DiagnosticsReport{
+		GeneratedAt:         time.Now(),
+		ProcessArchitecture: processArchitecture().String(),
+		Errors:              []string{ErrUnsupportedPlatform.Error()},
+	}, ErrUnsupportedPlatform
+}
+
+// InstallerLogPaths returns nil as no installer ever runs on this platform.
+func InstallerLogPaths() []string {
+	return nil
+}
+
+// TailInstallerLogs returns nil as no installer ever runs on this platform.
+func TailInstallerLogs(maxBytes int64) (map[string]string, error) {
+	return nil, nil
+}
+
+// Preflight reports that the runtime cannot be installed on this platform.
+func Preflight(ctx context.Context, options *InstallOptions) *PreflightReport {
+	report := &PreflightReport{}
+	report.block(CheckOSVersion, ErrUnsupportedPlatform, "The WebView2 runtime is only available on Windows")
+	return report
+}
+
+// LatestAvailableVersion returns ErrUnsupportedPlatform.
+func LatestAvailableVersion() (string, error) {
+	return "", ErrUnsupportedPlatform
+}
+
+// QueryLatestVersion returns ErrUnsupportedPlatform.
+func QueryLatestVersion(ctx context.Context, channel Channel) (string, error) {
+	return "", ErrUnsupportedPlatform
+}
+
+// CheckForUpdate returns ErrUnsupportedPlatform.
+func CheckForUpdate(ctx context.Context) (*UpdateInfo, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// SetHTTPClient does nothing as nothing is downloaded on this platform.
+func SetHTTPClient(client *http.Client) {}
+
+// DownloadBootstrapper returns ErrUnsupportedPlatform.
+func DownloadBootstrapper(ctx context.Context, destDir string) (string, error) {
+	return "", ErrUnsupportedPlatform
+}
+
+// DownloadBootstrapperWithOptions returns ErrUnsupportedPlatform.
+func DownloadBootstrapperWithOptions(ctx context.Context, destDir string, options *InstallOptions) (string, error) {
+	return "", ErrUnsupportedPlatform
+}
+
+// InstallUsingEmbeddedBootstrapper returns ErrUnsupportedPlatform.
+func InstallUsingEmbeddedBootstrapper() (bool, error) {
+	return false, ErrUnsupportedPlatform
+}
+
+// InstallUsingEmbeddedBootstrapperWithOptions returns ErrUnsupportedPlatform.
+func InstallUsingEmbeddedBootstrapperWithOptions(options *InstallOptions) (bool, error) {
+	return false, ErrUnsupportedPlatform
+}
+
+// InstallUsingEmbeddedBootstrapperWithContext returns ErrUnsupportedPlatform.
+func InstallUsingEmbeddedBootstrapperWithContext(ctx context.Context, options *InstallOptions) (bool, error) {
+	return false, ErrUnsupportedPlatform
+}
+
+// InstallUsingEmbeddedBootstrapperWithResult returns ErrUnsupportedPlatform. The result is never nil.
+func InstallUsingEmbeddedBootstrapperWithResult(ctx context.Context, options *InstallOptions) (*InstallResult, error) {
+	return unsupportedResult()
+}
+
+// InstallUsingProvidedBootstrapper returns ErrUnsupportedPlatform.
+func InstallUsingProvidedBootstrapper(bootstrapper []byte) (bool, error) {
+	return false, ErrUnsupportedPlatform
+}
+
+// InstallUsingProvidedBootstrapperWithContext returns ErrUnsupportedPlatform.
+func InstallUsingProvidedBootstrapperWithContext(ctx context.Context, bootstrapper io.Reader, options *InstallOptions) (bool, error) {
+	return false, ErrUnsupportedPlatform
+}
+
+// InstallUsingProvidedBootstrapperWithResult returns ErrUnsupportedPlatform. The result is never nil.
+func InstallUsingProvidedBootstrapperWithResult(ctx context.Context, bootstrapper io.Reader, options *InstallOptions) (*InstallResult, error) {
+	return unsupportedResult()
+}
+
+// InstallUsingBootstrapper returns ErrUnsupportedPlatform.
+func InstallUsingBootstrapper() (bool, error) {
+	return false, ErrUnsupportedPlatform
+}
+
+// InstallUsingBootstrapperWithOptions returns ErrUnsupportedPlatform.
+func InstallUsingBootstrapperWithOptions(options *InstallOptions) (bool, error) {
+	return false, ErrUnsupportedPlatform
+}
+
+// InstallUsingBootstrapperWithContext returns ErrUnsupportedPlatform.
+func InstallUsingBootstrapperWithContext(ctx context.Context, options *InstallOptions) (bool, error) {
+	return false, ErrUnsupportedPlatform
+}
+
+// InstallUsingBootstrapperWithResult returns ErrUnsupportedPlatform. The result is never nil.
+func InstallUsingBootstrapperWithResult(ctx context.Context, options *InstallOptions) (*InstallResult, error) {
+	return unsupportedResult()
+}
+
+// StandaloneInstallerFilenameForSystem returns a blank string as there is no installer for this platform.
+func StandaloneInstallerFilenameForSystem() string {
+	return ""
+}
+
+// InstallUsingStandaloneInstaller returns ErrUnsupportedPlatform. The result is never nil.
+func InstallUsingStandaloneInstaller(path string) (*InstallResult, error) {
+	return unsupportedResult()
+}
+
+// InstallUsingStandaloneInstallerWithContext returns ErrUnsupportedPlatform. The result is never nil.
+func InstallUsingStandaloneInstallerWithContext(ctx context.Context, path string, options *InstallOptions) (*InstallResult, error) {
+	return unsupportedResult()
+}
+
+// FromEmbeddedBootstrapper installs using the embedded bootstrapper, which is not embedded on this platform.
+func FromEmbeddedBootstrapper() InstallOption {
+	return FromBootstrapper(nil)
+}
+
+// Install returns ErrUnsupportedPlatform. The result is never nil.
+func Install(ctx context.Context, opts ...InstallOption) (*InstallResult, error) {
+	return unsupportedResult()
+}
+
+// StartInstall returns ErrUnsupportedPlatform.
+func StartInstall(ctx context.Context, options *InstallOptions) (<-chan InstallEvent, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// Upgrade returns ErrUnsupportedPlatform. The result is never nil.
+func Upgrade(ctx context.Context, options *InstallOptions) (*InstallResult, error) {
+	return unsupportedResult()
+}
+
+// UpdateUsingEdgeUpdate returns ErrUnsupportedPlatform. The result is never nil.
+func UpdateUsingEdgeUpdate(ctx context.Context, options *InstallOptions) (*InstallResult, error) {
+	return unsupportedResult()
+}
+
+// RepairInstallation returns ErrUnsupportedPlatform. The result is never nil.
+func RepairInstallation(ctx context.Context, options *RepairOptions) (*InstallResult, error) {
+	return unsupportedResult()
+}
+
+func unsupportedResult() (*InstallResult, error) {
+	return &InstallResult{Error: ErrUnsupportedPlatform}, ErrUnsupportedPlatform
+}
+
+// CleanupArtifacts does nothing as no installer is ever written on this platform.
+func CleanupArtifacts() error {
+	return nil
+}
+
+// DeployFixedVersion returns ErrUnsupportedPlatform.
+func DeployFixedVersion(archive string, destination string) (*FixedVersionRuntime, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// GetDeployedFixedVersion returns ErrUnsupportedPlatform.
+func GetDeployedFixedVersion(destination string) (*FixedVersionRuntime, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// RecommendedEnvironment returns ErrUnsupportedPlatform.
+func RecommendedEnvironment(appName string, fixedVersionFolder string) (*EnvironmentConfig, error) {
+	return nil, ErrUnsupportedPlatform
+}
+
+// Variables returns no variables as WebView2 does not run on this platform.
+func (e *EnvironmentConfig) Variables() map[string]string {
+	return map[string]string{}
+}
+
+// Apply does nothing as WebView2 does not run on this platform.
+func (e *EnvironmentConfig) Apply() error {
+	return nil
+}
+
+// RecommendedUserDataFolder returns ErrUnsupportedPlatform.
+func RecommendedUserDataFolder(appName string) (string, error) {
+	return "", ErrUnsupportedPlatform
+}
+
+// CreateUserDataFolder returns ErrUnsupportedPlatform.
+func CreateUserDataFolder(path string) error {
+	return ErrUnsupportedPlatform
+}
+
+// UserDataFolderSize returns ErrUnsupportedPlatform.
+func UserDataFolderSize(path string) (int64, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+// ClearUserDataFolderCache returns ErrUnsupportedPlatform.
+func ClearUserDataFolderCache(path string) error {
+	return ErrUnsupportedPlatform
+}
+
+// EnsureInstalled returns ErrUnsupportedPlatform.
+func EnsureInstalled(minVersion string, opts ...Option) error {
+	return ErrUnsupportedPlatform
+}
+
+// EnsureInstalledWithContext returns ErrUnsupportedPlatform.
+func EnsureInstalledWithContext(ctx context.Context, minVersion string, opts ...Option) error {
+	return ErrUnsupportedPlatform
+}
+
+// EnsureCompatibleWith returns ErrUnsupportedPlatform.
+func EnsureCompatibleWith(sdkVersion string, opts ...Option) error {
+	return ErrUnsupportedPlatform
+}
+
+// EnsureCompatibleWithContext returns ErrUnsupportedPlatform.
+func EnsureCompatibleWithContext(ctx context.Context, sdkVersion string, opts ...Option) error {
+	return ErrUnsupportedPlatform
+}
+
+// Confirm returns ErrUnsupportedPlatform.
+func Confirm(caption string, title string) (bool, error) {
+	return false, ErrUnsupportedPlatform
+}
+
+// ConfirmWithOwner returns ErrUnsupportedPlatform.
+func ConfirmWithOwner(owner uintptr, caption string, title string) (bool, error) {
+	return false, ErrUnsupportedPlatform
+}
+
+// Question returns ErrUnsupportedPlatform.
+func Question(caption string, title string) (bool, error) {
+	return false, ErrUnsupportedPlatform
+}
+
+// Prompt returns ErrUnsupportedPlatform.
+func Prompt(owner uintptr, caption string, title string, buttons Buttons, icon Icon) (Button, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+// Error returns ErrUnsupportedPlatform.
+func Error(caption string, title string) error {
+	return ErrUnsupportedPlatform
+}
+
+// ErrorWithOwner returns ErrUnsupportedPlatform.
+func ErrorWithOwner(owner uintptr, caption string, title string) error {
+	return ErrUnsupportedPlatform
+}
+
+// MessageBox returns ErrUnsupportedPlatform.
+func MessageBox(caption string, title string, flags uint) (int, error) {
+	return -1, ErrUnsupportedPlatform
+}
+
+// MessageBoxEx returns ErrUnsupportedPlatform.
+func MessageBoxEx(owner uintptr, caption string, title string, flags uint) (int, error) {
+	return -1, ErrUnsupportedPlatform
+}
+
+// TaskDialog returns ErrUnsupportedPlatform.
+func TaskDialog(config TaskDialogConfig) (int, error) {
+	return 0, ErrUnsupportedPlatform
+}
+
+// OpenInstallerDownloadWebpage returns ErrUnsupportedPlatform.
+func OpenInstallerDownloadWebpage() error {
+	return ErrUnsupportedPlatform
+}
+
+// ProgressDialog is a native progress window. It cannot be shown on this platform.
+type ProgressDialog struct{}
+
+// ShowProgressDialog returns ErrUnsupportedPlatform and the context unchanged.
+func ShowProgressDialog(ctx context.Context, owner uintptr, title string) (*ProgressDialog, context.Context, error) {
+	return nil, ctx, ErrUnsupportedPlatform
+}
+
+// SetStatus does nothing.
+func (p *ProgressDialog) SetStatus(status string) {}
+
+// SetProgress does nothing.
+func (p *ProgressDialog) SetProgress(done int64, total int64) {}
+
+// SetMarquee does nothing.
+func (p *ProgressDialog) SetMarquee() {}
+
+// Close does nothing.
+func (p *ProgressDialog) Close() {}
+
+// hwnd is a window handle.
+type hwnd uintptr
+
+// _SHELLEXECUTEINFO is the SHELLEXECUTEINFO struct, which only exists on Windows.
+type _SHELLEXECUTEINFO struct{}
+
+// ShellExecuteAndWait returns ErrUnsupportedPlatform.
+func ShellExecuteAndWait(hwnd hwnd, lpOperation, lpFile, lpParameters, lpDirectory string, nShowCmd int) error {
+	return ErrUnsupportedPlatform
+}
+
+// ShellExecuteNowait returns ErrUnsupportedPlatform.
+func ShellExecuteNowait(hwnd hwnd, lpOperation, lpFile, lpParameters, lpDirectory string, nShowCmd int) error {
+	return ErrUnsupportedPlatform
+}
+
+// ShellExecuteEx returns ErrUnsupportedPlatform.
+func ShellExecuteEx(pExecInfo *_SHELLEXECUTEINFO) error {
+	return ErrUnsupportedPlatform
+}
@@ -0,0 +1,309 @@
+//go:build windows
+// +build windows
+
+package webview2runtime
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"syscall"
+	"time"
+	"unsafe"
+)
+
+//go:embed MicrosoftEdgeWebview2Setup.exe
+var setupexe []byte
+
+// IsOlderThan returns true if the installed version is older than the given required version.
+// Returns error if something goes wrong.
+func (i *Info) IsOlderThan(requiredVersion string) (bool, error) {
+	return defaultDetector.IsOlderThan(i, requiredVersion)
+}
+
+// GetInstalledVersion returns the installed version of the webview2 runtime.
+// If there is no version installed, a blank string is returned.
+// The result is cached if SetDetectionCacheTTL has been called.
+func GetInstalledVersion() string {
+	return cachedInstalledVersion()
+}
+
+// Detect returns the installed runtime, using WebView2Loader.dll as the authoritative source and
+// falling back to the registry if the loader is unavailable. The result is cross-checked against the
+// registry: if both agree, the registry details are filled in, otherwise RegistryVersion records the
+// registered version. Returns nil if the runtime is not installed.
+// The result is cached if SetDetectionCacheTTL has been called.
+// Returns an error if the registry could not be read.
+func Detect() (*Info, error) {
+	start := time.Now()
+	info, cached, err := cachedDetect()
+	if cached {
+		return info, nil
+	}
+	event := DetectionTelemetry{Duration: time.Since(start), Err: err}
+	if info != nil {
+		event.Version = info.Version
+	}
+	getTelemetry().DetectionResult(event)
+	return info, err
+}
+
+// GetInstallation returns the newest installation of the runtime registered in the registry.
+// Both per-machine and per-user installations are checked, and the Scope of the result
+// shows which was found. Returns nil if the runtime is not installed.
+// Returns an error if the registry could not be read.
+func GetInstallation() (*Info, error) {
+	return defaultDetector.Installation()
+}
+
+// GetInstalledVersionTimed is the same as GetInstalledVersion but also returns how long detection took.
+// Useful for measuring the cost of detection during app startup.
+func GetInstalledVersionTimed() (string, time.Duration) {
+	start := time.Now()
+	version := GetInstalledVersion()
+	return version, time.Since(start)
+}
+
+// InstallUsingEmbeddedBootstrapper will download the bootstrapper from Microsoft and run it to install
+// the latest version of the runtime.
+// Returns true if the installer ran successfully.
+// Returns an error if something goes wrong
+func InstallUsingEmbeddedBootstrapper() (bool, error) {
+	return InstallUsingEmbeddedBootstrapperWithOptions(nil)
+}
+
+// InstallUsingEmbeddedBootstrapperWithOptions is the same as InstallUsingEmbeddedBootstrapper but
+// runs the installer using the given options.
+func InstallUsingEmbeddedBootstrapperWithOptions(options *InstallOptions) (bool, error) {
+	return InstallUsingEmbeddedBootstrapperWithContext(context.Background(), options)
+}
+
+// InstallUsingEmbeddedBootstrapperWithContext is the same as InstallUsingEmbeddedBootstrapperWithOptions but
+// the install is aborted, and the installer killed, if the context is cancelled or times out.
+// The options may be nil.
+func InstallUsingEmbeddedBootstrapperWithContext(ctx context.Context, options *InstallOptions) (bool, error) {
+	result, err := InstallUsingEmbeddedBootstrapperWithResult(ctx, options)
+	return result.Success, err
+}
+
+// InstallUsingEmbeddedBootstrapperWithResult is the same as InstallUsingEmbeddedBootstrapperWithContext but
+// returns the full result of the install, including the installer exit code. The result is never nil.
+func InstallUsingEmbeddedBootstrapperWithResult(ctx context.Context, options *InstallOptions) (*InstallResult, error) {
+	return Install(ctx, UsingOptions(options), FromEmbeddedBootstrapper())
+}
+
+// InstallUsingProvidedBootstrapper will write the given bootstrapper to a temp file and run it to install
+// the latest version of the runtime. This allows apps to embed their own copy of the bootstrapper
+// using go:embed.
+// Returns true if the installer ran successfully.
+// Returns an error if something goes wrong
+func InstallUsingProvidedBootstrapper(bootstrapper []byte) (bool, error) {
+	return InstallUsingProvidedBootstrapperWithContext(context.Background(), bytes.NewReader(bootstrapper), nil)
+}
+
+// InstallUsingProvidedBootstrapperWithContext is the same as InstallUsingProvidedBootstrapper but reads
+// the bootstrapper from the given reader and runs it using the given options.
+// The installer is killed if the context is cancelled or times out. The options may be nil.
+func InstallUsingProvidedBootstrapperWithContext(ctx context.Context, bootstrapper io.Reader, options *InstallOptions) (bool, error) {
+	result, err := InstallUsingProvidedBootstrapperWithResult(ctx, bootstrapper, options)
+	return result.Success, err
+}
+
+// InstallUsingProvidedBootstrapperWithResult is the same as InstallUsingProvidedBootstrapperWithContext but
+// returns the full result of the install, including the installer exit code. The result is never nil.
+func InstallUsingProvidedBootstrapperWithResult(ctx context.Context, bootstrapper io.Reader, options *InstallOptions) (*InstallResult, error) {
+	return Install(ctx, UsingOptions(options), FromBootstrapper(bootstrapper))
+}
+
+// installProvidedBootstrapper writes the bootstrapper to a temp file and runs it.
+func installProvidedBootstrapper(ctx context.Context, bootstrapper io.Reader, options *InstallOptions) (*InstallResult, error) {
+	err := options.validate()
+	if err != nil {
+		return &InstallResult{Error: err}, err
+	}
+	if options.dryRun() {
+		return planInstall(sourceProvided, "", options)
+	}
+	release, skipped, err := acquireInstallLockAndRecheck(ctx, options)
+	if err != nil {
+		return &InstallResult{Error: err}, err
+	}
+	defer release()
+	if skipped != nil {
+		return skipped, nil
+	}
+
+	installer, unlock, err := options.installerPath(os.TempDir())
+	if err != nil {
+		return &InstallResult{Error: err}, err
+	}
+	defer unlock()
+	err = writeInstaller(installer, bootstrapper)
+	if err != nil {
+		_ = options.cleanup(false, installer)
+		return &InstallResult{Installer: installer, Error: err}, err
+	}
+	return install(ctx, installerRun{path: installer, temporary: true}, options)
+}
+
+// writeInstaller writes the installer to the given path.
+func writeInstaller(path string, installer io.Reader) error {
+	out, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0755)
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, installer)
+	if err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// InstallUsingBootstrapper will extract the embedded bootstrapper from Microsoft and run it to install
+// the latest version of the runtime.
+// Returns true if the installer ran successfully.
+// Returns an error if something goes wrong
+func InstallUsingBootstrapper() (bool, error) {
+	return InstallUsingBootstrapperWithOptions(nil)
+}
+
+// InstallUsingBootstrapperWithOptions is the same as InstallUsingBootstrapper but
+// runs the installer using the given options.
+func InstallUsingBootstrapperWithOptions(options *InstallOptions) (bool, error) {
+	return InstallUsingBootstrapperWithContext(context.Background(), options)
+}
+
+// InstallUsingBootstrapperWithContext is the same as InstallUsingBootstrapperWithOptions but the download
+// is aborted, and the installer killed, if the context is cancelled or times out.
+// The options may be nil.
+func InstallUsingBootstrapperWithContext(ctx context.Context, options *InstallOptions) (bool, error) {
+	result, err := InstallUsingBootstrapperWithResult(ctx, options)
+	return result.Success, err
+}
+
+// InstallUsingBootstrapperWithResult is the same as InstallUsingBootstrapperWithContext but returns the
+// full result of the install, including the installer exit code, its decoded Reason and whether a
+// reboot is required. The result is never nil.
+func InstallUsingBootstrapperWithResult(ctx context.Context, options *InstallOptions) (*InstallResult, error) {
+	return Install(ctx, UsingOptions(options))
+}
+
+// installDownloadedBootstrapper downloads the bootstrapper to a temp file and runs it.
+func installDownloadedBootstrapper(ctx context.Context, options *InstallOptions) (*InstallResult, error) {
+	err := options.validate()
+	if err != nil {
+		return &InstallResult{Error: err}, err
+	}
+	if options.dryRun() {
+		return planInstall(sourceDownload, "", options)
+	}
+	release, skipped, err := acquireInstallLockAndRecheck(ctx, options)
+	if err != nil {
+		return &InstallResult{Error: err}, err
+	}
+	defer release()
+	if skipped != nil {
+		return skipped, nil
+	}
+
+	installer, unlock, err := options.installerPath(os.TempDir())
+	if err != nil {
+		return &InstallResult{Error: err}, err
+	}
+	defer unlock()
+	err = downloadBootstrapperTo(ctx, installer, options)
+	if err != nil {
+		_ = options.cleanup(false, installer)
+		return &InstallResult{Installer: installer, Error: err}, err
+	}
+	return install(ctx, installerRun{path: installer, temporary: true}, options)
+}
+
+// Confirm will prompt the user with a message and OK / CANCEL buttons.
+// Returns true if OK is selected by the user.
+// Returns an error if something went wrong.
+func Confirm(caption string, title string) (bool, error) {
+	return ConfirmWithOwner(0, caption, title)
+}
+
+// ConfirmWithOwner is the same as Confirm but the prompt is modal to the given owner window.
+func ConfirmWithOwner(owner uintptr, caption string, title string) (bool, error) {
+	var flags uint = 0x00000001 // MB_OKCANCEL
+	result, err := MessageBoxEx(owner, caption, title, flags)
+	if err != nil {
+		return false, err
+	}
+	return result == 1, nil
+}
+
+// Error will an error message to the user.
+// Returns an error if something went wrong.
+func Error(caption string, title string) error {
+	return ErrorWithOwner(0, caption, title)
+}
+
+// ErrorWithOwner is the same as Error but the message is modal to the given owner window.
+func ErrorWithOwner(owner uintptr, caption string, title string) error {
+	var flags uint = 0x00000010 // MB_ICONERROR
+	_, err := MessageBoxEx(owner, caption, title, flags)
+	return err
+}
+
+// MessageBox prompts the user with the given caption and title.
+// Flags may be provided to customise the dialog.
+// Returns an error if something went wrong.
+func MessageBox(caption string, title string, flags uint) (int, error) {
+	return MessageBoxEx(0, caption, title, flags)
+}
+
+// MessageBoxEx is the same as MessageBox but the dialog is modal to the given owner window.
+// An owner of 0 means the dialog has no owner.
+func MessageBoxEx(owner uintptr, caption string, title string, flags uint) (int, error) {
+	captionUTF16, err := syscall.UTF16PtrFromString(caption)
+	if err != nil {
+		return -1, err
+	}
+	titleUTF16, err := syscall.UTF16PtrFromString(title)
+	if err != nil {
+		return -1, err
+	}
+	ret, _, _ := procMessageBoxW.Call(
+		owner,
+		uintptr(unsafe.Pointer(captionUTF16)),
+		uintptr(unsafe.Pointer(titleUTF16)),
+		uintptr(flags))
+
+	return int(ret), nil
+}
+
+// OpenInstallerDownloadWebpage will open the browser on the WebView2 download page
+func OpenInstallerDownloadWebpage() error {
+	cmd := exec.Command("rundll32", "url.dll,FileProtocolHandler", "https://developer.microsoft.com/en-us/microsoft-edge/webview2/")
+	return cmd.Run()
+}
+
+// MustBeInstalled checks that a version of the runtime at least as new as minVersion is installed.
+// It never prompts the user or installs anything, making it suitable for CI and test setup.
+// Returns nil if an adequate runtime is installed, otherwise an error describing why not.
+func MustBeInstalled(minVersion string) error {
+	info, err := Detect()
+	if err != nil {
+		return fmt.Errorf("unable to detect the webview2 runtime: %w", err)
+	}
+	status, err := defaultDetector.statusOf(info, minVersion)
+	if err != nil {
+		return fmt.Errorf("unable to compare installed version %s with %s: %w", info.Version, minVersion, err)
+	}
+	switch status {
+	case StatusNotInstalled:
+		return fmt.Errorf("%w: version %s or newer is required", ErrNotInstalled, minVersion)
+	case StatusInstalledTooOld:
+		return fmt.Errorf("webview2 runtime %s is installed but version %s or newer is required", info.Version, minVersion)
+	}
+	return nil
+}